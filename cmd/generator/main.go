@@ -9,6 +9,7 @@ import (
 
 func main() {
 	http.HandleFunc("/", handler.GenerateHandler)
+	http.HandleFunc("/parse", handler.ParseHandler)
 
 	address := ":8080"
 	fmt.Printf("Server is running on http://localhost%s\n", address)
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"mybatis-plus-generator/internal/model"
+	"strconv"
+	"strings"
+)
+
+// apiDocExamples 是常见类型在接口文档中的示例值，字符串等类型不提供示例
+var apiDocExamples = map[string]string{
+	"Integer":       "1",
+	"Long":          "1",
+	"Short":         "1",
+	"Byte":          "1",
+	"BigDecimal":    "1.00",
+	"Double":        "1.0",
+	"Float":         "1.0",
+	"Boolean":       "true",
+	"LocalDateTime": "2024-01-01T00:00:00",
+	"LocalDate":     "2024-01-01",
+	"LocalTime":     "00:00:00",
+	"UUID":          "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+}
+
+// apiDocClass 返回类上的 @Schema/@ApiModel 注解 (不含 @)，没有表注释时返回空
+func apiDocClass(style model.APIDocStyle, comment string) string {
+	comment = strings.TrimSpace(comment)
+	if style == "" || comment == "" {
+		return ""
+	}
+	if style == model.APIDocSwagger2 {
+		return "ApiModel(description = " + strconv.Quote(comment) + ")"
+	}
+	return "Schema(description = " + strconv.Quote(comment) + ")"
+}
+
+// apiDocField 返回字段上的 @Schema/@ApiModelProperty 注解 (不含 @)，描述来自列注释
+func apiDocField(style model.APIDocStyle, comment, javaType string, required bool) string {
+	if style == "" {
+		return ""
+	}
+	var args []string
+	if comment = strings.TrimSpace(comment); comment != "" {
+		if style == model.APIDocSwagger2 {
+			args = append(args, "value = "+strconv.Quote(comment))
+		} else {
+			args = append(args, "description = "+strconv.Quote(comment))
+		}
+	}
+	if example, ok := apiDocExamples[javaType]; ok {
+		args = append(args, "example = "+strconv.Quote(example))
+	}
+	if required {
+		if style == model.APIDocSwagger2 {
+			args = append(args, "required = true")
+		} else {
+			args = append(args, "requiredMode = Schema.RequiredMode.REQUIRED")
+		}
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	if style == model.APIDocSwagger2 {
+		return "ApiModelProperty(" + strings.Join(args, ", ") + ")"
+	}
+	return "Schema(" + strings.Join(args, ", ") + ")"
+}
+
+// apiDocImports 返回注解需要的导入
+func apiDocImports(class string, fields []model.Field) []string {
+	var imports []string
+	if class != "" {
+		imports = append(imports, annotationImports[annotationName(class)])
+	}
+	for _, f := range fields {
+		if f.APIDoc != "" {
+			imports = append(imports, annotationImports[annotationName(f.APIDoc)])
+		}
+	}
+	return mergeImports(nil, imports...)
+}
+
+// annotationName 取注解名，如 Size(max = 64) -> Size
+func annotationName(annotation string) string {
+	name, _, _ := strings.Cut(annotation, "(")
+	return name
+}
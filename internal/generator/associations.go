@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"mybatis-plus-generator/internal/model"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// LinkAssociations 根据同批生成的表之间的单列外键，为两端的模板数据补充关联信息
+// 持有外键的一方得到多对一关联，被引用的一方得到一对多关联；引用批次外的表、自引用和复合外键会被忽略
+// Flex 通过 DO 上的 @RelationOneToMany/@RelationManyToOne 查询关联，其余 MyBatis 系 ORM 生成聚合 VO、
+// 嵌套 resultMap 和 join 查询，这类查询按主键取数，因此要求发起查询的表只有一个主键
+func LinkAssociations(datas []model.TemplateData, tables []model.TableInfo, paths model.PathConfig) {
+	if paths.Language != model.LanguageJava || paths.ORM == model.ORMJPA || paths.DO.Style == model.DOStyleRecord {
+		return
+	}
+	flex := paths.ORM == model.ORMMyBatisFlex
+
+	byTable := make(map[string]int, len(tables))
+	for i, table := range tables {
+		byTable[strings.ToLower(table.TableName)] = i
+	}
+
+	for i, table := range tables {
+		// 同一外键可能同时以列级 REFERENCES 和表级 FOREIGN KEY 声明
+		seen := make(map[string]bool)
+		for _, fk := range table.ForeignKeys {
+			j, ok := byTable[strings.ToLower(fk.RefTable)]
+			if !ok || i == j || len(fk.Columns) != 1 || len(fk.RefColumns) > 1 {
+				continue
+			}
+			key := strings.ToLower(fk.Columns[0] + "->" + fk.RefTable)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			child, parent := &datas[i], &datas[j]
+			selfField, ok := findField(child.Fields, fk.Columns[0])
+			if !ok {
+				continue
+			}
+			var targetField model.Field
+			if len(fk.RefColumns) == 1 {
+				targetField, ok = findField(parent.Fields, fk.RefColumns[0])
+			} else {
+				ok = len(parent.IdFields) == 1
+				if ok {
+					targetField = parent.IdFields[0]
+				}
+			}
+			if !ok {
+				continue
+			}
+
+			// 多对一：属性名取外键列去掉 _id 后的部分 (order_id -> order)，否则取被引用表的实体名
+			property := decapitalize(parent.EntityName)
+			if lower := strings.ToLower(selfField.Name); strings.HasSuffix(lower, "_id") && len(lower) > 3 {
+				property = strcase.ToLowerCamel(selfField.Name[:len(selfField.Name)-3])
+			}
+			if flex || len(child.IdFields) == 1 {
+				addAssociation(child, *parent, selfField, targetField, false, property)
+			}
+
+			// 一对多：属性名取子表实体名去掉父表实体名前缀后的复数形式 (OrderItem -> items)
+			short := strings.TrimPrefix(child.EntityName, parent.EntityName)
+			if short == "" {
+				short = child.EntityName
+			}
+			if flex || len(parent.IdFields) == 1 {
+				addAssociation(parent, *child, targetField, selfField, true, decapitalize(plural(short)))
+			}
+		}
+	}
+
+	for i := range datas {
+		if len(datas[i].Associations) == 0 {
+			continue
+		}
+		if flex {
+			linkFlexRelations(&datas[i])
+			continue
+		}
+		// 聚合 VO 放在配置的 VO 包中，未配置时放在 DO 包的同级 vo 包中
+		switch idx := strings.LastIndex(datas[i].DOPackage, "."); {
+		case paths.VOPath != "":
+			datas[i].VOPackage = extractPackageName(paths.VOPath)
+		case idx >= 0:
+			datas[i].VOPackage = datas[i].DOPackage[:idx] + ".vo"
+		default:
+			datas[i].VOPackage = "vo"
+		}
+	}
+}
+
+func addAssociation(data *model.TemplateData, target model.TemplateData, selfField, targetField model.Field, many bool, property string) {
+	// 同名属性 (同一张表被多个外键引用，或与已有字段重名) 追加连接列名区分
+	if associationPropertyTaken(*data, property) {
+		property += capitalize(selfField.PropertyName)
+	}
+	javaType := target.DOClassName
+	if many {
+		javaType = "List<" + target.DOClassName + ">"
+	}
+	name := data.EntityName + "With" + capitalize(property)
+	data.Associations = append(data.Associations, model.Association{
+		Many:              many,
+		Property:          property,
+		JavaType:          javaType,
+		VOClassName:       name + "VO",
+		Method:            "selectWith" + capitalize(property) + "ById",
+		ResultMapID:       name + "ResultMap",
+		ColumnPrefix:      strcase.ToSnake(property) + "__",
+		SelfColumn:        selfField.Column(),
+		SelfProperty:      selfField.PropertyName,
+		TargetTable:       target.SQLTableName,
+		TargetColumn:      targetField.Column(),
+		TargetProperty:    targetField.PropertyName,
+		TargetDOClassName: target.DOClassName,
+		TargetDOPackage:   target.DOPackage,
+		TargetFields:      target.Fields,
+	})
+}
+
+func associationPropertyTaken(data model.TemplateData, property string) bool {
+	for _, f := range data.Fields {
+		if f.PropertyName == property {
+			return true
+		}
+	}
+	for _, a := range data.Associations {
+		if a.Property == property {
+			return true
+		}
+	}
+	return false
+}
+
+// linkFlexRelations 为 Flex DO 补充关联注解和关联类型的导入
+func linkFlexRelations(data *model.TemplateData) {
+	for _, a := range data.Associations {
+		if a.Many {
+			data.MybatisPlusImports = mergeImports(data.MybatisPlusImports, "com.mybatisflex.annotation.RelationOneToMany")
+			data.Imports = mergeImports(data.Imports, "java.util.List")
+		} else {
+			data.MybatisPlusImports = mergeImports(data.MybatisPlusImports, "com.mybatisflex.annotation.RelationManyToOne")
+		}
+		if a.TargetDOPackage != data.DOPackage {
+			data.Imports = mergeImports(data.Imports, a.TargetDOPackage+"."+a.TargetDOClassName)
+		}
+	}
+}
+
+func findField(fields []model.Field, column string) (model.Field, bool) {
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, column) {
+			return f, true
+		}
+	}
+	return model.Field{}, false
+}
+
+// plural 返回英文单词的复数形式，只处理常见的规则变化
+func plural(word string) string {
+	lower := strings.ToLower(word)
+	switch {
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return word + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
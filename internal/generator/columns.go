@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"mybatis-plus-generator/internal/model"
+	"strings"
+	"unicode"
+)
+
+// mysqlReservedWords 是常被用作列名的 MySQL 保留字
+var mysqlReservedWords = toSet(
+	"accessible", "add", "all", "alter", "analyze", "and", "as", "asc", "before", "between",
+	"bigint", "binary", "blob", "both", "by", "call", "cascade", "case", "change", "char",
+	"character", "check", "collate", "column", "condition", "constraint", "continue", "convert",
+	"create", "cross", "cube", "cume_dist", "current_date", "current_time", "current_timestamp",
+	"current_user", "cursor", "database", "databases", "dec", "decimal", "declare", "default",
+	"delayed", "delete", "dense_rank", "desc", "describe", "distinct", "div", "double", "drop",
+	"dual", "each", "else", "elseif", "empty", "enclosed", "escaped", "except", "exists", "exit",
+	"explain", "false", "fetch", "first_value", "float", "for", "force", "foreign", "from",
+	"fulltext", "function", "generated", "get", "grant", "group", "grouping", "groups", "having",
+	"if", "ignore", "in", "index", "infile", "inner", "inout", "insert", "int", "integer",
+	"interval", "into", "is", "iterate", "join", "json_table", "key", "keys", "kill", "lag",
+	"last_value", "lateral", "lead", "leading", "leave", "left", "like", "limit", "linear",
+	"lines", "load", "localtime", "localtimestamp", "lock", "long", "loop", "match", "maxvalue",
+	"member", "mod", "modifies", "natural", "not", "nth_value", "ntile", "null", "numeric", "of",
+	"on", "optimize", "option", "optionally", "or", "order", "out", "outer", "outfile", "over",
+	"partition", "percent_rank", "precision", "primary", "procedure", "purge", "range", "rank",
+	"read", "reads", "real", "recursive", "references", "regexp", "release", "rename", "repeat",
+	"replace", "require", "resignal", "restrict", "return", "revoke", "right", "rlike", "row",
+	"row_number", "rows", "schema", "schemas", "select", "sensitive", "separator", "set", "show",
+	"signal", "smallint", "spatial", "specific", "sql", "sqlexception", "sqlstate", "sqlwarning",
+	"ssl", "starting", "stored", "straight_join", "system", "table", "terminated", "then", "to",
+	"trailing", "trigger", "true", "undo", "union", "unique", "unlock", "unsigned", "update",
+	"usage", "use", "using", "utc_date", "utc_time", "utc_timestamp", "values", "varbinary",
+	"varchar", "varying", "virtual", "when", "where", "while", "window", "with", "write", "xor",
+	"year_month", "zerofill",
+)
+
+// postgresReservedWords 是 PostgreSQL 中不能直接作为列名使用的保留字
+var postgresReservedWords = toSet(
+	"all", "analyse", "analyze", "and", "any", "array", "as", "asc", "asymmetric",
+	"authorization", "binary", "both", "case", "cast", "check", "collate", "collation", "column",
+	"concurrently", "constraint", "create", "cross", "current_catalog", "current_date",
+	"current_role", "current_schema", "current_time", "current_timestamp", "current_user",
+	"default", "deferrable", "desc", "distinct", "do", "else", "end", "except", "false", "fetch",
+	"for", "foreign", "freeze", "from", "full", "grant", "group", "having", "ilike", "in",
+	"initially", "inner", "intersect", "into", "is", "isnull", "join", "lateral", "leading",
+	"left", "like", "limit", "localtime", "localtimestamp", "natural", "not", "notnull", "null",
+	"offset", "on", "only", "or", "order", "outer", "overlaps", "placing", "primary",
+	"references", "returning", "right", "select", "session_user", "similar", "some", "symmetric",
+	"system_user", "table", "tablesample", "then", "to", "trailing", "true", "union", "unique",
+	"user", "using", "variadic", "verbose", "when", "where", "window", "with",
+)
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// applyColumnMappings 为无法依赖驼峰自动映射的字段设置 MappedColumn，
+// 模板据此输出 @TableField / @Column 等显式映射注解
+func applyColumnMappings(fields []model.Field, dbType string) {
+	for i := range fields {
+		column := fields[i].Name
+		switch {
+		case needsQuote(column, dbType):
+			fields[i].MappedColumn = quoteIdentifier(column, dbType)
+		case camelToUnderline(fields[i].PropertyName) != strings.ToLower(column):
+			fields[i].MappedColumn = column
+		default:
+			fields[i].MappedColumn = ""
+		}
+	}
+}
+
+// camelToUnderline 与 MyBatis-Plus StringUtils.camelToUnderline 的规则一致
+func camelToUnderline(property string) string {
+	var sb strings.Builder
+	for i, r := range property {
+		if unicode.IsUpper(r) && i > 0 {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// needsQuote 判断列名在 SQL 中是否必须加引号：保留字、以数字开头、包含特殊字符，
+// 或 PostgreSQL 中包含大写字母 (未加引号时会被转为小写)
+func needsQuote(column, dbType string) bool {
+	lower := strings.ToLower(column)
+	if isPostgres(dbType) {
+		if postgresReservedWords[lower] || lower != column {
+			return true
+		}
+	} else if mysqlReservedWords[lower] {
+		return true
+	}
+
+	for i, r := range column {
+		if i == 0 && unicode.IsDigit(r) {
+			return true
+		}
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteIdentifier(name, dbType string) string {
+	if isPostgres(dbType) {
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func isPostgres(dbType string) bool {
+	dbType = strings.ToLower(dbType)
+	return dbType == "postgresql" || dbType == "postgres"
+}
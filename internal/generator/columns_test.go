@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"testing"
+
+	"mybatis-plus-generator/internal/model"
+)
+
+func TestApplyColumnMappings(t *testing.T) {
+	tests := []struct {
+		dbType, column, property, want string
+	}{
+		{"mysql", "user_name", "userName", ""},
+		{"mysql", "userName", "userName", "userName"},
+		{"mysql", "order", "order", "`order`"},
+		{"mysql", "unit-price", "unitPrice", "`unit-price`"},
+		{"mysql", "a`b", "aB", "`a``b`"},
+		{"postgresql", "order", "order", `"order"`},
+		{"postgresql", "Status", "status", `"Status"`},
+		{"postgresql", "user", "user", `"user"`},
+		{"mysql", "user", "user", ""},
+		{"postgresql", "2fa_code", "_2faCode", `"2fa_code"`},
+		{"mysql", "2fa_code", "_2faCode", "`2fa_code`"},
+		{"mysql", "code_2fa", "code2fa", "code_2fa"},
+	}
+	for _, tt := range tests {
+		fields := []model.Field{{Name: tt.column, PropertyName: tt.property}}
+		applyColumnMappings(fields, tt.dbType)
+		if got := fields[0].MappedColumn; got != tt.want {
+			t.Errorf("%s %s MappedColumn = %q, want %q", tt.dbType, tt.column, got, tt.want)
+		}
+	}
+}
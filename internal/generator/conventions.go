@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"embed"
+	"fmt"
+	"mybatis-plus-generator/internal/model"
+	"path/filepath"
+	"strings"
+)
+
+// fillHandlerClassName 是生成的 MetaObjectHandler 实现类名，整个项目只应有一个
+const fillHandlerClassName = "MyMetaObjectHandler"
+
+// fillHandlerData 是 MetaObjectHandler 模板的数据
+type fillHandlerData struct {
+	Package string
+	Fields  []model.Field // 各表的填充字段，属性名和类型相同的只保留一个
+}
+
+// GenerateFillHandler 汇总同批生成的表中的填充字段，生成唯一的 MetaObjectHandler，放在 DAO 目录的 handler 子包下
+// strictInsertFill/strictUpdateFill 只填充类型一致的属性，因此不同表中同名但类型不同的字段可以共存
+func GenerateFillHandler(datas []model.TemplateData, paths model.PathConfig, templatesFS embed.FS) error {
+	if paths.ORM != model.ORMMyBatisPlus || len(datas) == 0 {
+		return nil
+	}
+	handler := fillHandlerData{Package: datas[0].DAOPackage + ".handler"}
+	seen := make(map[string]bool)
+	for _, data := range datas {
+		for _, f := range data.FillFields {
+			key := f.PropertyName + " " + f.JavaType
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			handler.Fields = append(handler.Fields, f)
+		}
+	}
+	if len(handler.Fields) == 0 {
+		return nil
+	}
+	ext := ".java"
+	if paths.Language == model.LanguageKotlin {
+		ext = ".kt"
+	}
+	templateName := model.Path(paths.ORM, paths.Language) + "/meta_object_handler.tmpl"
+	return renderTemplate(templatesFS, templateName, handler, filepath.Join(paths.DAOPath, "handler", fillHandlerClassName+ext))
+}
+
+// temporalValues 是时间类型字段的自动填充值
+var temporalValues = map[string]string{
+	"LocalDateTime": "LocalDateTime.now()",
+	"LocalDate":     "LocalDate.now()",
+	"LocalTime":     "LocalTime.now()",
+	"Date":          "new Date()",
+}
+
+// applyColumnRules 按列名约定标记逻辑删除、乐观锁、自动填充和多租户字段，主键不参与
+func applyColumnRules(fields []model.Field, rules model.ColumnRules) {
+	logicDelete, version := lowerSet(rules.LogicDelete), lowerSet(rules.Version)
+	insertFill, updateFill := lowerSet(rules.InsertFill), lowerSet(rules.UpdateFill)
+	for i := range fields {
+		if fields[i].IsId {
+			continue
+		}
+		column := strings.ToLower(fields[i].Name)
+		if logicDelete[column] {
+			fields[i].LogicDelete = true
+		}
+		if version[column] {
+			fields[i].Version = true
+		}
+		if updateFill[column] {
+			fields[i].Fill = model.FillInsertUpdate
+		} else if insertFill[column] {
+			fields[i].Fill = model.FillInsert
+		}
+		if rules.Tenant != "" && strings.EqualFold(column, rules.Tenant) {
+			fields[i].Tenant = true
+		}
+	}
+}
+
+func lowerSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[strings.ToLower(w)] = true
+	}
+	return set
+}
+
+// tableField 返回 MyBatis-Plus 非主键字段 (含复合主键列) 的 @TableField 注解 (不含 @)，不需要时返回空
+func tableField(f model.Field) string {
+	if (f.IsId && !f.KeyPart) || (f.MappedColumn == "" && f.Fill == "" && f.TypeHandler == "") {
+		return ""
+	}
+	if f.Fill == "" && f.TypeHandler == "" {
+		return fmt.Sprintf("TableField(%q)", f.MappedColumn)
+	}
+	var args []string
+	if f.MappedColumn != "" {
+		args = append(args, fmt.Sprintf("value = %q", f.MappedColumn))
+	}
+	if f.Fill != "" {
+		args = append(args, "fill = FieldFill."+string(f.Fill))
+	}
+	if f.TypeHandler != "" {
+		args = append(args, "typeHandler = "+simpleClassName(f.TypeHandler)+".class")
+	}
+	return "TableField(" + strings.Join(args, ", ") + ")"
+}
+
+// flexColumn 返回 MyBatis-Flex 非主键字段的 @Column 注解 (不含 @)，不需要时返回空
+// Flex 没有 Java 端的字段填充，时间类型的填充列使用 now() 作为插入/更新时的 SQL 值
+func flexColumn(f model.Field) string {
+	if f.IsId {
+		return ""
+	}
+	var args []string
+	if f.MappedColumn != "" {
+		args = append(args, fmt.Sprintf("value = %q", f.MappedColumn))
+	}
+	if f.LogicDelete {
+		args = append(args, "isLogicDelete = true")
+	}
+	if f.Version {
+		args = append(args, "version = true")
+	}
+	if f.Tenant {
+		args = append(args, "tenantId = true")
+	}
+	if _, ok := temporalValues[f.JavaType]; ok && f.Fill != "" {
+		args = append(args, `onInsertValue = "now()"`)
+		if f.Fill == model.FillInsertUpdate {
+			args = append(args, `onUpdateValue = "now()"`)
+		}
+	}
+	if f.TypeHandler != "" {
+		args = append(args, "typeHandler = "+simpleClassName(f.TypeHandler)+".class")
+	}
+	switch {
+	case len(args) == 0:
+		return ""
+	case len(args) == 1 && f.MappedColumn != "":
+		return fmt.Sprintf("Column(%q)", f.MappedColumn)
+	}
+	return "Column(" + strings.Join(args, ", ") + ")"
+}
+
+// fillValue 返回 MetaObjectHandler 中填充字段使用的表达式，非时间类型的字段 (如 create_by) 取当前操作人
+func fillValue(javaType string) string {
+	if value, ok := temporalValues[javaType]; ok {
+		return value
+	}
+	return "currentOperator()"
+}
+
+// kotlinFillValue 是 fillValue 的 Kotlin 写法，构造对象时不需要 new，泛型参数需要显式指定
+func kotlinFillValue(javaType string) string {
+	if value, ok := temporalValues[javaType]; ok {
+		return strings.TrimPrefix(value, "new ")
+	}
+	return "currentOperator<" + kotlinType(javaType) + ">()"
+}
@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"mybatis-plus-generator/internal/model"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// DefaultEnumRegex 匹配注释中的枚举项，如 0-禁用、1:启用、ORDER_CREATE(下单)，匹配前全角标点会转换为半角
+const DefaultEnumRegex = `(?P<code>[A-Za-z0-9_]+)\s*[-:=(]\s*(?P<desc>[^\s,;()\-:=]+)`
+
+// enumValueImports 是各 ORM 标记枚举中存入数据库的属性的注解
+var enumValueImports = map[model.ORM]string{
+	model.ORMMyBatisPlus: "com.baomidou.mybatisplus.annotation.EnumValue",
+	model.ORMMyBatisFlex: "com.mybatisflex.annotation.EnumValue",
+}
+
+// enumCodeTypes 是可以替换为枚举的列类型
+var enumCodeTypes = toSet("Integer", "Short", "Long", "String")
+
+var fullWidthReplacer = strings.NewReplacer(
+	"（", "(", "）", ")", "：", ":", "，", ",", "、", ",", "；", ";", "＝", "=", "－", "-", "　", " ",
+)
+
+var javaIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// applyEnums 为 ENUM 类型的列和注释中列出了至少两个枚举项的列生成枚举，并将字段类型替换为枚举类
+// 主键、逻辑删除、乐观锁、自动填充和多租户字段保持原类型
+func applyEnums(fields []model.Field, entityName string, paths model.PathConfig) []model.EnumClass {
+	pattern := paths.EnumRegex
+	if pattern == "" {
+		pattern = DefaultEnumRegex
+	}
+	// 正则在读取请求时已经校验过
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+
+	var enums []model.EnumClass
+	for i, f := range fields {
+		if f.IsId || f.LogicDelete || f.Version || f.Fill != "" || f.Tenant || !enumCodeTypes[f.JavaType] {
+			continue
+		}
+		comment, items := enumItems(re, f.Comment)
+		var constants []model.EnumConstant
+		if len(f.EnumValues) > 0 {
+			constants = enumConstants(f.EnumValues, items, f.JavaType)
+		} else if len(items) >= 2 {
+			codes := make([]string, len(items))
+			for j, item := range items {
+				codes[j] = item[0]
+			}
+			constants = enumConstants(codes, items, f.JavaType)
+		}
+		if len(constants) == 0 {
+			continue
+		}
+
+		// 属性名已包含实体名时不再重复，如 order 表的 orderStatus -> OrderStatusEnum；
+		// 同表还有 status 字段时保留实体名，避免与 status 的枚举重名
+		name := capitalize(f.PropertyName)
+		if lower := strings.ToLower(name); !strings.HasPrefix(lower, strings.ToLower(entityName)) || hasProperty(fields, name[len(entityName):]) {
+			name = entityName + name
+		}
+		if comment == "" {
+			comment = f.PropertyName
+		}
+		enums = append(enums, model.EnumClass{
+			ClassName:       name + "Enum",
+			Package:         extractPackageName(paths.EnumPath),
+			Comment:         comment,
+			Property:        f.PropertyName,
+			CodeType:        f.JavaType,
+			EnumValueImport: enumValueImports[paths.ORM],
+			Constants:       constants,
+		})
+		fields[i].JavaType = name + "Enum"
+	}
+	return enums
+}
+
+// enumItems 返回注释中枚举项之前的说明和各枚举项的 code、desc
+// 同一注释中既有数字又有非数字的 code 时只保留数字，避免把 status: 0-disabled 中的 status 当作枚举项
+func enumItems(re *regexp.Regexp, comment string) (string, [][2]string) {
+	comment = fullWidthReplacer.Replace(comment)
+	codeIndex, descIndex := re.SubexpIndex("code"), re.SubexpIndex("desc")
+	if codeIndex < 0 || descIndex < 0 {
+		return strings.TrimSpace(comment), nil
+	}
+	matches := re.FindAllStringSubmatchIndex(comment, -1)
+	if len(matches) == 0 {
+		return strings.TrimSpace(comment), nil
+	}
+	numeric := 0
+	for _, m := range matches {
+		if isInteger(comment[m[2*codeIndex]:m[2*codeIndex+1]]) {
+			numeric++
+		}
+	}
+	if numeric > 0 && numeric < len(matches) {
+		// 非数字的 code 可能吞掉了后面的枚举项 (status: 0 中的 0)，从它之后重新匹配
+		matches = matches[:0]
+		for pos := 0; pos < len(comment); {
+			m := re.FindStringSubmatchIndex(comment[pos:])
+			if m == nil {
+				break
+			}
+			for j := range m {
+				if m[j] >= 0 {
+					m[j] += pos
+				}
+			}
+			if isInteger(comment[m[2*codeIndex]:m[2*codeIndex+1]]) {
+				matches = append(matches, m)
+				pos = m[1]
+			} else {
+				pos = m[2*codeIndex+1]
+			}
+		}
+		if len(matches) == 0 {
+			return strings.TrimSpace(comment), nil
+		}
+	}
+
+	items := make([][2]string, len(matches))
+	for i, m := range matches {
+		items[i] = [2]string{comment[m[2*codeIndex]:m[2*codeIndex+1]], comment[m[2*descIndex]:m[2*descIndex+1]]}
+	}
+	return strings.Trim(comment[:matches[0][0]], " (:,;"), items
+}
+
+// enumConstants 由 code 构造枚举常量，codeType 为数值类型时 code 必须都是整数，描述优先取注释中的说明
+func enumConstants(codes []string, items [][2]string, codeType string) []model.EnumConstant {
+	descs := make(map[string]string, len(items))
+	for _, item := range items {
+		descs[item[0]] = item[1]
+	}
+	seen := make(map[string]bool, len(codes))
+	constants := make([]model.EnumConstant, 0, len(codes))
+	for i, code := range codes {
+		desc := descs[code]
+		if desc == "" {
+			desc = code
+		}
+
+		literal := strconv.Quote(code)
+		if codeType != "String" {
+			if !isInteger(code) {
+				return nil
+			}
+			literal = code
+			if codeType == "Long" {
+				literal += "L"
+			} else if codeType == "Short" {
+				literal = "(short) " + code
+			}
+		}
+
+		// 常量名取 code (in-progress -> IN_PROGRESS)，code 是数字时取英文描述，都不可用时按 code 或序号命名
+		name := strcase.ToScreamingSnake(code)
+		switch {
+		case !isInteger(code) && javaIdentifier.MatchString(name):
+		case javaIdentifier.MatchString(strcase.ToScreamingSnake(desc)):
+			name = strcase.ToScreamingSnake(desc)
+		case isInteger(code) && !strings.HasPrefix(code, "-"):
+			name = "VALUE_" + code
+		default:
+			name = "VALUE_" + strconv.Itoa(i)
+		}
+		if seen[name] {
+			name = "VALUE_" + strconv.Itoa(i)
+		}
+		seen[name] = true
+		constants = append(constants, model.EnumConstant{Name: name, Code: literal, Desc: desc})
+	}
+	return constants
+}
+
+func hasProperty(fields []model.Field, property string) bool {
+	for _, f := range fields {
+		if strings.EqualFold(f.PropertyName, property) {
+			return true
+		}
+	}
+	return false
+}
+
+func isInteger(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
+
+// enumImports 返回字段中使用的、与 pkg 不在同一个包的枚举类导入
+func enumImports(enums []model.EnumClass, fields []model.Field, pkg string) []string {
+	var imports []string
+	for _, f := range fields {
+		for _, e := range enums {
+			if e.ClassName == f.JavaType && e.Package != pkg {
+				imports = append(imports, e.Package+"."+e.ClassName)
+			}
+		}
+	}
+	return imports
+}
+
+// findEnum 返回类名对应的枚举
+func findEnum(enums []model.EnumClass, javaType string) (model.EnumClass, bool) {
+	for _, e := range enums {
+		if e.ClassName == javaType {
+			return e, true
+		}
+	}
+	return model.EnumClass{}, false
+}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"mybatis-plus-generator/internal/model"
+)
+
+func TestEnumItems(t *testing.T) {
+	re := regexp.MustCompile(DefaultEnumRegex)
+	tests := []struct {
+		comment string
+		prefix  string
+		items   [][2]string
+	}{
+		{"状态：0-禁用，1-启用", "状态", [][2]string{{"0", "禁用"}, {"1", "启用"}}},
+		{"status: 0-disabled, 1-enabled", "status", [][2]string{{"0", "disabled"}, {"1", "enabled"}}},
+		{"类型 ORDER_CREATE(下单)、ORDER_PAY(支付)", "类型", [][2]string{{"ORDER_CREATE", "下单"}, {"ORDER_PAY", "支付"}}},
+		{"备注", "备注", nil},
+	}
+	for _, tt := range tests {
+		prefix, items := enumItems(re, tt.comment)
+		if prefix != tt.prefix || !reflect.DeepEqual(items, tt.items) {
+			t.Errorf("enumItems(%q) = %q, %v, want %q, %v", tt.comment, prefix, items, tt.prefix, tt.items)
+		}
+	}
+}
+
+func TestEnumConstants(t *testing.T) {
+	got := enumConstants([]string{"0", "1"}, [][2]string{{"0", "disabled"}, {"1", "启用"}}, "Integer")
+	want := []model.EnumConstant{
+		{Name: "DISABLED", Code: "0", Desc: "disabled"},
+		{Name: "VALUE_1", Code: "1", Desc: "启用"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("enumConstants = %+v, want %+v", got, want)
+	}
+
+	got = enumConstants([]string{"in-progress", "done"}, nil, "String")
+	want = []model.EnumConstant{
+		{Name: "IN_PROGRESS", Code: `"in-progress"`, Desc: "in-progress"},
+		{Name: "DONE", Code: `"done"`, Desc: "done"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("enumConstants = %+v, want %+v", got, want)
+	}
+
+	if got := enumConstants([]string{"a", "b"}, nil, "Integer"); got != nil {
+		t.Errorf("数值类型的 code 不是整数时应返回 nil, got %+v", got)
+	}
+}
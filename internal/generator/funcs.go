@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// javadocWrapWidth 是注释单行的最大字符数，超过后自动换行
+const javadocWrapWidth = 80
+
+// templateFuncs 是所有模板可用的辅助函数
+var templateFuncs = template.FuncMap{
+	"javadoc":         javadoc,
+	"javadocEscape":   javadocEscape,
+	"jsdoc":           jsdoc,
+	"xml":             xmlEscape,
+	"capitalize":      capitalize,
+	"decapitalize":    decapitalize,
+	"javaTypeImport":  getJavaTypeImport,
+	"importsOf":       collectImports,
+	"kotlinImportsOf": collectKotlinImports,
+	"tableField":      tableField,
+	"flexColumn":      flexColumn,
+	"fillValue":       fillValue,
+	"kotlinFillValue": kotlinFillValue,
+	"kotlinType":      kotlinType,
+	"lower":           strings.ToLower,
+	"yaml":            yamlString,
+	"openAPISchema":   openAPISchema,
+	"openAPIResponse": openAPIResponse,
+}
+
+var javadocReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"@", "&#64;",
+	"*/", "*&#47;",
+)
+
+var xmlReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+// javadoc 将注释渲染为缩进 indent 个空格的 Javadoc 块 (以换行结尾)，
+// 多行注释逐行输出，过长的行自动折行；注释为空时返回空字符串
+// 用法: {{.Comment | javadoc 4}}
+func javadoc(indent int, comment string) string {
+	return docBlock(indent, comment, javadocEscape)
+}
+
+// jsdoc 与 javadoc 格式相同，用于 TypeScript，只转义会提前结束注释的 */，不做 HTML 转义
+// 用法: {{.Comment | jsdoc 2}}
+func jsdoc(indent int, comment string) string {
+	return docBlock(indent, comment, jsdocEscape)
+}
+
+// docBlock 将注释渲染为 /** */ 块，每行先经过 escape 转义
+func docBlock(indent int, comment string, escape func(string) string) string {
+	lines := commentLines(comment)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	pad := strings.Repeat(" ", indent)
+	var sb strings.Builder
+	sb.WriteString(pad + "/**\n")
+	for _, line := range lines {
+		for _, wrapped := range wrapLine(escape(line), javadocWrapWidth) {
+			sb.WriteString(strings.TrimRight(pad+" * "+wrapped, " ") + "\n")
+		}
+	}
+	sb.WriteString(pad + " */\n")
+	return sb.String()
+}
+
+// javadocEscape 转义会破坏 Java 注释或 Javadoc 的字符
+func javadocEscape(s string) string {
+	return javadocReplacer.Replace(s)
+}
+
+// jsdocEscape 将 */ 转义为 *\/，JSDoc 中的 <、& 和 @ 保持原样
+func jsdocEscape(s string) string {
+	return strings.ReplaceAll(s, "*/", "*\\/")
+}
+
+// xmlEscape 转义 XML 文本和属性中的特殊字符
+func xmlEscape(s string) string {
+	return xmlReplacer.Replace(s)
+}
+
+// capitalize 将首字母大写，用于拼接 getter/setter 名称，如 userName -> UserName
+func capitalize(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// commentLines 按换行拆分注释，去除首尾空行和行尾空白
+func commentLines(comment string) []string {
+	comment = strings.ReplaceAll(comment, "\r\n", "\n")
+	comment = strings.Trim(comment, "\n\r\t ")
+	if comment == "" {
+		return nil
+	}
+
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r\t ")
+	}
+	return lines
+}
+
+// wrapLine 将一行按宽度折行，优先在空格处断开，中文等无空格文本按字符断开
+func wrapLine(line string, width int) []string {
+	var lines []string
+	for utf8.RuneCountInString(line) > width {
+		runes := []rune(line)
+		cut := width
+		for i := width; i > width/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		line = strings.TrimLeft(string(runes[cut:]), " ")
+	}
+	return append(lines, line)
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/iancoleman/strcase"
 )
 
+// identityTypes 是可以由数据库自增生成 (GenerationType.IDENTITY) 的主键类型
+var identityTypes = toSet("Integer", "Long", "Short", "BigInteger")
+
 // PrepareTemplateData 准备用于渲染模板的所有数据
 func PrepareTemplateData(tableInfo model.TableInfo, paths model.PathConfig) model.TemplateData {
 	// 提取包名
@@ -21,57 +24,413 @@ func PrepareTemplateData(tableInfo model.TableInfo, paths model.PathConfig) mode
 	daoPackage := extractPackageName(paths.DAOPath)
 	daoImplPackage := extractPackageName(paths.DAOImplPath)
 
+	// 计算类名
+	naming := paths.Naming
+	baseName := stripTableName(tableInfo.TableName, naming)
+	mapperPattern := defaultMapperPattern
+	if paths.ORM == model.ORMJPA {
+		mapperPattern = defaultRepositoryPattern
+	}
+	mapperClassName := className(naming.MapperPattern, mapperPattern, baseName)
+	doClassName := className(naming.DOPattern, defaultDOPattern, baseName)
+
+	// 计算属性名及需要显式映射的列，改名后的属性会通过注解映射回原列
+	fields := tableInfo.ToTemplateFields(naming)
+	renames := escapeJavaIdentifiers(fields, naming, paths.Language)
+	renames = dedupeProperties(fields, renames, paths.Language)
+	applyColumnMappings(fields, tableInfo.DbType)
+	// 所有 ORM 都标记约定字段，使请求/响应对象排除这些列，对应的注解只在 MyBatis-Plus/Flex 的模板中生成
+	applyColumnRules(fields, paths.ColumnRules)
+
+	// JSON 列和枚举字段在计算导入和派生对象之前替换类型
+	nestedClasses := applyJSONTypes(fields, doClassName, paths)
+	var enums []model.EnumClass
+	if paths.EnumPath != "" {
+		enums = applyEnums(fields, strcase.ToCamel(baseName), paths)
+	}
+
+	idCount := 0
+	for _, field := range fields {
+		if field.IsId {
+			idCount++
+		}
+	}
+
+	var idFields, fillFields []model.Field
+	for i := range fields {
+		fields[i].JdbcType = jdbcType(fields[i].Type)
+		fields[i].KeyPart = fields[i].IsId && idCount > 1
+		fields[i].Identity = fields[i].IsId && idCount == 1 && fields[i].AutoIncrement && identityTypes[fields[i].JavaType]
+		if paths.Language == model.LanguageKotlin {
+			fields[i].KotlinType = kotlinType(fields[i].JavaType)
+		}
+		fields[i].APIDoc = apiDocField(paths.APIDoc, fields[i].Comment, fields[i].JavaType, fields[i].NotNull)
+		if fields[i].IsId {
+			idFields = append(idFields, fields[i])
+		}
+		if fields[i].Fill != "" && paths.ORM == model.ORMMyBatisPlus {
+			fillFields = append(fillFields, fields[i])
+		}
+	}
+
+	sqlTableName := tableInfo.TableName
+	if needsQuote(sqlTableName, tableInfo.DbType) {
+		sqlTableName = quoteIdentifier(sqlTableName, tableInfo.DbType)
+	}
+
+	// CRUD 语句依赖 BaseResultMap 和 Base_Column_List
+	xml := paths.XML
+	if xml.CRUD {
+		xml.ResultMap = true
+	}
+
+	// 未选择 @Data 或 @Getter/@Setter 时默认使用 @Data
+	doConfig := paths.DO
+	if doConfig.Style == "" {
+		doConfig.Style = model.DOStyleLombok
+	}
+	if doConfig.Style == model.DOStyleLombok && !doConfig.Data && !doConfig.GetterSetter {
+		doConfig.Data = true
+	}
+
+	// 表中包含父类的全部列时才继承父类，继承的字段不再在 DO 中声明
+	doFields, baseFields := splitBaseFields(fields, paths.BaseDO)
+	if len(baseFields) > 0 && doConfig.Style == model.DOStyleLombok {
+		doConfig.EqualsAndHashCode, doConfig.CallSuper = true, true
+	}
+
 	// 准备模板数据
 	data := model.TemplateData{
 		ORM:              paths.ORM,
+		Language:         paths.Language,
 		DOPackage:        doPackage,
 		MapperPackage:    mapperPackage,
 		DAOPackage:       daoPackage,
 		DAOImplPackage:   daoImplPackage,
-		DOClassName:      strcase.ToCamel(tableInfo.TableName) + "DO",
-		MapperClassName:  strcase.ToCamel(tableInfo.TableName) + "Mapper",
-		MapperVarName:    strcase.ToLowerCamel(tableInfo.TableName) + "Mapper",
-		DAOClassName:     strcase.ToCamel(tableInfo.TableName) + "DAO",
-		DAOImplClassName: strcase.ToCamel(tableInfo.TableName) + "DAOImpl",
+		EntityName:       strcase.ToCamel(baseName),
+		DOClassName:      doClassName,
+		MapperClassName:  mapperClassName,
+		MapperVarName:    decapitalize(mapperClassName),
+		DAOClassName:     className(naming.DAOPattern, defaultDAOPattern, baseName),
+		DAOImplClassName: className(naming.DAOImplPattern, defaultDAOImplPattern, baseName),
 		TableName:        tableInfo.TableName,
-		Fields:           tableInfo.ToTemplateFields(),
-		MapperNamespace:  mapperPackage + "." + strcase.ToCamel(tableInfo.TableName) + "Mapper",
+		TableComment:     tableInfo.Comment,
+		SQLTableName:     sqlTableName,
+		Fields:           fields,
+		DOFields:         doFields,
+		IdFields:         idFields,
+		IdType:           idType(idFields, doClassName),
+		MapperNamespace:  mapperPackage + "." + mapperClassName,
+		Renames:          renames,
+		XML:              xml,
+		DO:               doConfig,
+		FillFields:       fillFields,
+		Enums:            enums,
+		NestedClasses:    nestedClasses,
 	}
 
 	// 处理 Imports
-	data.Imports = collectImports(tableInfo.Fields)
-	data.MybatisPlusImports = getMybatisPlusImports(tableInfo.Fields)
+	data.Imports = doImports(doFields, doConfig, paths.Language)
+	data.Imports = mergeImports(data.Imports, enumImports(enums, doFields, doPackage)...)
+	if len(nestedClasses) > 0 {
+		data.Imports = mergeImports(data.Imports, "com.fasterxml.jackson.annotation.JsonIgnoreProperties")
+	}
+	for _, f := range fields {
+		// 通过 TypeHandler 读写的字段需要 MyBatis-Plus 自动构建 resultMap
+		data.AutoResultMap = data.AutoResultMap || (f.TypeHandler != "" && paths.ORM == model.ORMMyBatisPlus)
+	}
+	data.MybatisPlusImports = getMybatisPlusImports(paths.ORM, doFields)
+	data.DOAnnotations, data.LombokImports = lombokAnnotations(doConfig)
+	if paths.ORM == model.ORMJPA && len(idFields) > 1 && doConfig.Style == model.DOStyleLombok {
+		// 复合主键内部类 PK 使用 @Data @NoArgsConstructor @AllArgsConstructor
+		data.LombokImports = mergeImports(data.LombokImports, "lombok.AllArgsConstructor", "lombok.Data", "lombok.NoArgsConstructor")
+	}
+	if paths.APIDoc != "" {
+		// 类注解的描述取表注释
+		class := apiDocClass(paths.APIDoc, tableInfo.Comment)
+		if class != "" {
+			data.DOAnnotations = append(data.DOAnnotations, "@"+class)
+		}
+		data.Imports = mergeImports(data.Imports, apiDocImports(class, doFields)...)
+	}
+	if paths.Language == model.LanguageKotlin {
+		data.IdType = kotlinType(data.IdType)
+	}
+
+	if len(baseFields) > 0 {
+		data.BaseClassName = paths.BaseDO.ClassName
+		data.BaseDO = baseTemplateData(data, baseFields, paths)
+		if data.BaseDO.DOPackage != doPackage {
+			data.Imports = mergeImports(data.Imports, data.BaseDO.DOPackage+"."+data.BaseClassName)
+		}
+	}
+
+	data.Models = modelClasses(data, paths)
+	if paths.ConverterPath != "" {
+		data.Converter = converterData(data, paths, baseName)
+	}
+	if paths.ServicePath != "" {
+		data.Service = serviceData(data, paths, baseName)
+	}
+	if paths.OpenAPIPath != "" {
+		data.OpenAPI = openAPIData(data, paths)
+	}
+	if paths.TypeScriptPath != "" {
+		data.TypeScript = typeScriptData(data, paths)
+	}
 
 	return data
 }
 
+// doImports 收集 DO 中字段类型所需的导入
+func doImports(fields []model.Field, cfg model.DOConfig, lang model.Language) []string {
+	if lang == model.LanguageKotlin {
+		return collectKotlinImports(fields)
+	}
+	imports := collectImports(fields)
+	if cfg.Style == model.DOStylePlain && hasJavaType(fields, "byte[]") {
+		// toString 中使用 Arrays.toString 输出 byte[]
+		imports = mergeImports(imports, "java.util.Arrays")
+	}
+	return imports
+}
+
+// splitBaseFields 将字段拆分为 DO 自身的字段和从父类继承的字段，表中缺少父类的任一列时不继承
+func splitBaseFields(fields []model.Field, base model.BaseDOConfig) (doFields, baseFields []model.Field) {
+	columns := lowerSet(base.Columns)
+	if base.ClassName == "" || len(columns) == 0 {
+		return fields, nil
+	}
+	for _, field := range fields {
+		if columns[strings.ToLower(field.Name)] {
+			baseFields = append(baseFields, field)
+		} else {
+			doFields = append(doFields, field)
+		}
+	}
+	if len(baseFields) != len(columns) {
+		return fields, nil
+	}
+	return doFields, baseFields
+}
+
+// baseTemplateData 返回生成父类使用的模板数据，父类只声明继承的字段，不带表注解
+func baseTemplateData(data model.TemplateData, baseFields []model.Field, paths model.PathConfig) *model.TemplateData {
+	base := data
+	base.DOClassName = paths.BaseDO.ClassName
+	if paths.BaseDO.Package != "" {
+		base.DOPackage = paths.BaseDO.Package
+	}
+	base.DOFields = baseFields
+	base.BaseClassName, base.BaseDO = "", nil
+
+	// 抽象父类不能使用 @Builder，也不需要 @EqualsAndHashCode(callSuper = true)
+	cfg := data.DO
+	cfg.Builder, cfg.EqualsAndHashCode, cfg.CallSuper = false, false, false
+	base.DO = cfg
+	base.DOAnnotations, base.LombokImports = lombokAnnotations(cfg)
+	base.Imports = mergeImports(doImports(baseFields, cfg, paths.Language), apiDocImports("", baseFields)...)
+	base.Imports = mergeImports(base.Imports, enumImports(data.Enums, baseFields, base.DOPackage)...)
+
+	tableAnnotations := toSet("com.baomidou.mybatisplus.annotation.TableName", "com.mybatisflex.annotation.Table")
+	base.MybatisPlusImports = nil
+	for _, imp := range getMybatisPlusImports(paths.ORM, baseFields) {
+		if !tableAnnotations[imp] {
+			base.MybatisPlusImports = append(base.MybatisPlusImports, imp)
+		}
+	}
+	return &base
+}
+
+const commonTemplates = "templates/common/*.tmpl"
+
+// voTemplate 是各 MyBatis 系 ORM 共用的聚合 VO 模板
+const voTemplate = "templates/vo/association_vo.tmpl"
+
+// modelTemplate 是 CreateRequest、UpdateRequest、Query 和 VO 共用的模板
+const modelTemplate = "templates/model/model.tmpl"
+
+// converterTemplate 是 DO 与请求/响应对象之间的 MapStruct 转换器模板
+const converterTemplate = "templates/model/converter.tmpl"
+
+// enumTemplate 是由 ENUM 类型或列注释推导出的枚举类模板
+const enumTemplate = "templates/enum/enum.tmpl"
+
+// openAPITemplate 是描述 Controller 增删改查接口的 OpenAPI 3 文档模板
+const openAPITemplate = "templates/openapi/openapi.yaml.tmpl"
+
+// typeScriptTemplates 下是前端类型定义 types.ts.tmpl 和各请求库对应的接口请求模板，如 axios.ts.tmpl
+const typeScriptTemplates = "templates/typescript"
+
+// serviceTemplates 下是各 ORM 共用的 Service 接口和 Controller 模板，ServiceImpl 位于各 ORM 目录
+const serviceTemplates = "templates/service"
+
+// ValidateTemplateData 检查表能否按当前配置生成，批量生成时应在写入任何文件之前检查所有表
+func ValidateTemplateData(data model.TemplateData, paths model.PathConfig) error {
+	if paths.ORM == model.ORMJPA && len(data.IdFields) == 0 {
+		return fmt.Errorf("JPA 实体必须包含主键，表 %s 没有主键", data.TableName)
+	}
+	if data.Service != nil && len(data.IdFields) != 1 {
+		return fmt.Errorf("Service/Controller 按单列主键增删改查，表 %s 的主键列数为 %d", data.TableName, len(data.IdFields))
+	}
+	return nil
+}
+
 // GenerateFiles 根据模板和数据生成所有代码文件
 func GenerateFiles(data model.TemplateData, paths model.PathConfig, templatesFS embed.FS) error {
 	// templates/mybatis-flex templates/mybatis-plus
-	pathPrefix := model.Path(paths.ORM)
+	// Kotlin 模板位于 templates/kotlin/ 下
+	pathPrefix := model.Path(paths.ORM, paths.Language)
+	// XML 映射与语言无关，Kotlin 也使用 Java 模板目录下的 mapper.xml.tmpl
+	xmlTemplate := model.Path(paths.ORM, model.LanguageJava) + "/mapper.xml.tmpl"
+	ext := ".java"
+	if paths.Language == model.LanguageKotlin {
+		ext = ".kt"
+	}
 	templateMappings := map[string]string{
-		pathPrefix + "/do.tmpl":         filepath.Join(paths.DOPath, data.DOClassName+".java"),
-		pathPrefix + "/mapper.tmpl":     filepath.Join(paths.MapperPath, data.MapperClassName+".java"),
-		pathPrefix + "/dao.tmpl":        filepath.Join(paths.DAOPath, data.DAOClassName+".java"),
-		pathPrefix + "/dao_impl.tmpl":   filepath.Join(paths.DAOImplPath, data.DAOImplClassName+".java"),
-		pathPrefix + "/mapper.xml.tmpl": filepath.Join(paths.XMLPath, data.MapperClassName+".xml"),
+		pathPrefix + "/do.tmpl":       filepath.Join(paths.DOPath, data.DOClassName+ext),
+		pathPrefix + "/mapper.tmpl":   filepath.Join(paths.MapperPath, data.MapperClassName+ext),
+		pathPrefix + "/dao.tmpl":      filepath.Join(paths.DAOPath, data.DAOClassName+ext),
+		pathPrefix + "/dao_impl.tmpl": filepath.Join(paths.DAOImplPath, data.DAOImplClassName+ext),
+		xmlTemplate:                   filepath.Join(paths.XMLPath, data.MapperClassName+".xml"),
+	}
+	if paths.ORM == model.ORMDynamicSQL {
+		templateMappings[pathPrefix+"/support.tmpl"] = filepath.Join(paths.MapperPath, data.EntityName+"DynamicSqlSupport.java")
+	}
+	if paths.ORM == model.ORMJPA {
+		// JPA 不使用 MyBatis XML
+		delete(templateMappings, xmlTemplate)
+	}
+
+	if err := ValidateTemplateData(data, paths); err != nil {
+		return err
 	}
 
 	for templateName, outputPath := range templateMappings {
-		tmplFile, err := template.New(filepath.Base(templateName)).ParseFS(templatesFS, templateName)
-		if err != nil {
-			return fmt.Errorf("解析嵌入的模板文件 %s 失败: %w", templateName, err)
+		if err := renderTemplate(templatesFS, templateName, data, outputPath); err != nil {
+			return err
+		}
+	}
+
+	if data.BaseDO != nil {
+		// 父类只在第一次遇到包含这些列的表时生成，已存在时不覆盖
+		baseDir := packageDir(paths.DOPath, data.DOPackage, data.BaseDO.DOPackage)
+		basePath := filepath.Join(baseDir, data.BaseDO.DOClassName+ext)
+		if _, err := os.Stat(basePath); os.IsNotExist(err) {
+			if err := renderTemplate(templatesFS, pathPrefix+"/base_do.tmpl", *data.BaseDO, basePath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if data.Service != nil {
+		if err := generateServiceFiles(data, paths, pathPrefix, templatesFS); err != nil {
+			return err
+		}
+	}
+
+	for _, class := range data.Models {
+		outputPath := filepath.Join(modelPath(paths, class.Kind), class.ClassName+ext)
+		if err := renderTemplate(templatesFS, modelTemplate, modelData{TemplateData: data, Model: class}, outputPath); err != nil {
+			return err
+		}
+	}
+
+	if data.Converter != nil {
+		outputPath := filepath.Join(paths.ConverterPath, data.Converter.ClassName+ext)
+		if err := renderTemplate(templatesFS, converterTemplate, data, outputPath); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range data.Enums {
+		outputPath := filepath.Join(paths.EnumPath, e.ClassName+ext)
+		if err := renderTemplate(templatesFS, enumTemplate, enumData{TemplateData: data, Enum: e}, outputPath); err != nil {
+			return err
+		}
+	}
+
+	if data.OpenAPI != nil {
+		outputPath := filepath.Join(paths.OpenAPIPath, data.OpenAPI.FileName)
+		if err := renderTemplate(templatesFS, openAPITemplate, data, outputPath); err != nil {
+			return err
+		}
+	}
+
+	if ts := data.TypeScript; ts != nil {
+		if err := renderTemplate(templatesFS, typeScriptTemplates+"/types.ts.tmpl", data, filepath.Join(paths.TypeScriptPath, ts.FileName)); err != nil {
+			return err
+		}
+		if ts.Client != "" {
+			templateName := typeScriptTemplates + "/" + string(ts.Client) + ".ts.tmpl"
+			if err := renderTemplate(templatesFS, templateName, data, filepath.Join(paths.TypeScriptPath, ts.ClientFileName)); err != nil {
+				return err
+			}
 		}
+	}
 
-		if err := generateFromTemplate(tmplFile, data, outputPath); err != nil {
-			return fmt.Errorf("failed to generate file from template %s: %w", tmplFile, err)
+	// 聚合 VO 与 DO 的样式一致，Flex 的关联直接声明在 DO 上；配置了 VO 路径时与 XxxVO 放在一起
+	if data.VOPackage != "" {
+		voDir := paths.VOPath
+		if voDir == "" {
+			voDir = packageDir(paths.DOPath, data.DOPackage, data.VOPackage)
 		}
+		for _, association := range data.Associations {
+			vo := associationVOData{TemplateData: data, Association: association}
+			if err := renderTemplate(templatesFS, voTemplate, vo, filepath.Join(voDir, association.VOClassName+ext)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// modelData 是请求/响应对象模板的数据
+type modelData struct {
+	model.TemplateData
+	Model model.ModelClass
+}
 
+// enumData 是枚举类模板的数据
+type enumData struct {
+	model.TemplateData
+	Enum model.EnumClass
+}
+
+// associationVOData 是聚合 VO 模板的数据，Association 为 VO 中的关联属性
+type associationVOData struct {
+	model.TemplateData
+	Association model.Association
+}
+
+func renderTemplate(templatesFS embed.FS, templateName string, data interface{}, outputPath string) error {
+	// templates/common 下是各 ORM 共用的子模板
+	tmplFile, err := template.New(filepath.Base(templateName)).Funcs(templateFuncs).ParseFS(templatesFS, templateName, commonTemplates)
+	if err != nil {
+		return fmt.Errorf("解析嵌入的模板文件 %s 失败: %w", templateName, err)
 	}
 
+	if err := generateFromTemplate(tmplFile, data, outputPath); err != nil {
+		return fmt.Errorf("failed to generate file from template %s: %w", templateName, err)
+	}
 	return nil
 }
 
+// packageDir 根据 DO 目录及其包名推算同一源码根目录下另一个包的目录，无法推算时使用 DO 目录
+func packageDir(doPath, doPackage, pkg string) string {
+	if pkg == doPackage {
+		return doPath
+	}
+	dir := filepath.ToSlash(filepath.Clean(doPath))
+	rel := strings.ReplaceAll(doPackage, ".", "/")
+	if !strings.HasSuffix(dir, "/"+rel) {
+		return doPath
+	}
+	root := strings.TrimSuffix(dir, rel)
+	return filepath.FromSlash(root + strings.ReplaceAll(pkg, ".", "/"))
+}
+
 func generateFromTemplate(tmpl *template.Template, data interface{}, outputFile string) error {
 	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
 		return fmt.Errorf("creating directory %s failed: %w", filepath.Dir(outputFile), err)
@@ -89,25 +448,150 @@ func generateFromTemplate(tmpl *template.Template, data interface{}, outputFile
 	return nil
 }
 
-func getMybatisPlusImports(fields []model.Field) []string {
-	imports := []string{
-		"com.baomidou.mybatisplus.annotation.TableName",
-	}
-	hasId := false
+// getMybatisPlusImports 收集 DO 上 ORM 注解所需的导入
+func getMybatisPlusImports(orm model.ORM, fields []model.Field) []string {
+	idCount := 0
+	var hasTableField, hasFlexColumn, hasFill, hasLogicDelete, hasVersion, hasIdentity bool
+	var typeHandlers []string
 	for _, field := range fields {
+		if field.TypeHandler != "" {
+			typeHandlers = append(typeHandlers, field.TypeHandler)
+		}
 		if field.IsId {
-			hasId = true
-			break
+			idCount++
+		}
+		hasTableField = hasTableField || tableField(field) != ""
+		hasFlexColumn = hasFlexColumn || flexColumn(field) != ""
+		hasFill = hasFill || field.Fill != ""
+		hasLogicDelete = hasLogicDelete || field.LogicDelete
+		hasVersion = hasVersion || field.Version
+		hasIdentity = hasIdentity || field.Identity
+	}
+	hasId := idCount > 0
+
+	var imports []string
+	switch orm {
+	case model.ORMMyBatis, model.ORMDynamicSQL:
+		// DO 是普通 POJO，映射在 XML 或 DynamicSqlSupport 中
+		return imports
+	case model.ORMJPA:
+		imports = append(imports, "jakarta.persistence.Column", "jakarta.persistence.Entity", "jakarta.persistence.Table")
+		if hasId {
+			imports = append(imports, "jakarta.persistence.Id")
+		}
+		if hasIdentity {
+			imports = append(imports, "jakarta.persistence.GeneratedValue", "jakarta.persistence.GenerationType")
+		}
+		if idCount > 1 {
+			imports = append(imports, "jakarta.persistence.IdClass")
+		}
+	case model.ORMTkMybatis:
+		imports = append(imports, "javax.persistence.Column", "javax.persistence.Table")
+		if hasId {
+			imports = append(imports, "javax.persistence.Id")
+		}
+		if hasIdentity {
+			imports = append(imports, "javax.persistence.GeneratedValue", "javax.persistence.GenerationType")
+		}
+	case model.ORMMyBatisFlex:
+		imports = append(imports, "com.mybatisflex.annotation.Table")
+		if hasId {
+			imports = append(imports, "com.mybatisflex.annotation.Id")
 		}
+		if idCount == 1 {
+			imports = append(imports, "com.mybatisflex.annotation.KeyType")
+		}
+		if hasFlexColumn {
+			imports = append(imports, "com.mybatisflex.annotation.Column")
+		}
+		imports = append(imports, typeHandlers...)
+	default:
+		imports = append(imports, "com.baomidou.mybatisplus.annotation.TableName")
+		// 复合主键不使用 @TableId，由 DAO 按全部主键列操作
+		if idCount == 1 {
+			imports = append(imports, "com.baomidou.mybatisplus.annotation.IdType")
+			imports = append(imports, "com.baomidou.mybatisplus.annotation.TableId")
+		}
+		if hasTableField {
+			imports = append(imports, "com.baomidou.mybatisplus.annotation.TableField")
+		}
+		if hasFill {
+			imports = append(imports, "com.baomidou.mybatisplus.annotation.FieldFill")
+		}
+		if hasLogicDelete {
+			imports = append(imports, "com.baomidou.mybatisplus.annotation.TableLogic")
+		}
+		if hasVersion {
+			imports = append(imports, "com.baomidou.mybatisplus.annotation.Version")
+		}
+		imports = append(imports, typeHandlers...)
+	}
+	return mergeImports(nil, imports...)
+}
+
+// lombokAnnotations 返回 DO 类上的 Lombok 注解及其导入，非 Lombok 风格时都为空
+func lombokAnnotations(cfg model.DOConfig) (annotations []string, imports []string) {
+	if cfg.Style != model.DOStyleLombok {
+		return nil, nil
 	}
-	if hasId {
-		imports = append(imports, "com.baomidou.mybatisplus.annotation.IdType")
-		imports = append(imports, "com.baomidou.mybatisplus.annotation.TableId")
+	if cfg.Data {
+		annotations = append(annotations, "@Data")
+		imports = append(imports, "lombok.Data")
+	}
+	if cfg.GetterSetter {
+		annotations = append(annotations, "@Getter", "@Setter")
+		imports = append(imports, "lombok.Getter", "lombok.Setter")
+	}
+	if cfg.Builder {
+		annotations = append(annotations, "@Builder", "@NoArgsConstructor", "@AllArgsConstructor")
+		imports = append(imports, "lombok.Builder", "lombok.NoArgsConstructor", "lombok.AllArgsConstructor")
+	}
+	if cfg.Chain {
+		annotations = append(annotations, "@Accessors(chain = true)")
+		imports = append(imports, "lombok.experimental.Accessors")
+	}
+	if cfg.EqualsAndHashCode {
+		annotations = append(annotations, fmt.Sprintf("@EqualsAndHashCode(callSuper = %t)", cfg.CallSuper))
+		imports = append(imports, "lombok.EqualsAndHashCode")
+	}
+	sort.Strings(imports)
+	return annotations, imports
+}
+
+// mergeImports 合并导入并去重排序
+func mergeImports(imports []string, extra ...string) []string {
+	set := toSet(imports...)
+	for _, imp := range extra {
+		if !set[imp] {
+			set[imp] = true
+			imports = append(imports, imp)
+		}
 	}
 	sort.Strings(imports)
 	return imports
 }
 
+func hasJavaType(fields []model.Field, javaType string) bool {
+	for _, field := range fields {
+		if field.JavaType == javaType {
+			return true
+		}
+	}
+	return false
+}
+
+// idType 返回主键的 Java 类型，复合主键时为 DO 中的 PK 内部类
+func idType(idFields []model.Field, doClassName string) string {
+	switch len(idFields) {
+	case 0:
+		return ""
+	case 1:
+		return idFields[0].JavaType
+	default:
+		return doClassName + ".PK"
+	}
+}
+
 func collectImports(fields []model.Field) []string {
 	importMap := make(map[string]bool)
 	for _, field := range fields {
@@ -142,6 +626,7 @@ func getJavaTypeImport(javaType string) string {
 		"Set":           "java.util.Set",
 		"HashSet":       "java.util.HashSet",
 		"Duration":      "java.time.Duration",
+		"JsonNode":      "com.fasterxml.jackson.databind.JsonNode",
 	}
 
 	// 泛型处理
@@ -168,6 +653,9 @@ func extractPackageName(path string) string {
 			if parts[i+1] == "test" && parts[i+2] == "java" {
 				return joinValidParts(parts[i+3:])
 			}
+			if (parts[i+1] == "main" || parts[i+1] == "test") && parts[i+2] == "kotlin" {
+				return joinValidParts(parts[i+3:])
+			}
 		}
 	}
 
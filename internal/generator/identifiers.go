@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"mybatis-plus-generator/internal/model"
+	"strings"
+	"unicode"
+)
+
+// 重命名属性时使用的默认前缀和后缀
+const (
+	defaultPropertyPrefix = "field"
+	defaultPropertySuffix = "Field"
+)
+
+// javaKeywords 是不能用作 Java 标识符的关键字和字面量
+var javaKeywords = toSet(
+	"abstract", "assert", "boolean", "break", "byte", "case", "catch", "char", "class", "const",
+	"continue", "default", "do", "double", "else", "enum", "extends", "final", "finally", "float",
+	"for", "goto", "if", "implements", "import", "instanceof", "int", "interface", "long", "native",
+	"new", "package", "private", "protected", "public", "return", "short", "static", "strictfp",
+	"super", "switch", "synchronized", "this", "throw", "throws", "transient", "try", "void",
+	"volatile", "while", "true", "false", "null", "_",
+)
+
+// escapeJavaIdentifiers 将不是合法 Java 标识符的属性名改名，并返回改名记录
+// Java 关键字追加后缀 (class -> classField)，数字开头的名称追加前缀 (2faCode -> field2faCode)，
+// 非 ASCII 字符会被去除，去除后为空时使用前缀加字段序号；生成 Kotlin 时同样避开 Kotlin 关键字
+func escapeJavaIdentifiers(fields []model.Field, naming model.NamingConfig, lang model.Language) []model.PropertyRename {
+	prefix := naming.PropertyPrefix
+	if prefix == "" {
+		prefix = defaultPropertyPrefix
+	}
+	suffix := naming.PropertySuffix
+	if suffix == "" {
+		suffix = defaultPropertySuffix
+	}
+
+	var renames []model.PropertyRename
+	for i := range fields {
+		original := fields[i].PropertyName
+		name, reason := stripInvalidIdentifierChars(original), ""
+
+		// strcase 会直接丢弃非 ASCII 字符，所以需要根据列名判断
+		if name != original || !isASCII(fields[i].Name) {
+			reason = "包含非 ASCII 或非法字符"
+		}
+		if name == "" {
+			name = fmt.Sprintf("%s%d", prefix, i+1)
+		} else if javaKeywords[name] {
+			name, reason = name+suffix, "Java 关键字"
+		} else if lang == model.LanguageKotlin && kotlinKeywords[name] {
+			name, reason = name+suffix, "Kotlin 关键字"
+		} else if first := name[0]; first >= '0' && first <= '9' {
+			name, reason = prefix+name, "以数字开头"
+		}
+
+		if reason != "" {
+			fields[i].PropertyName = name
+			renames = append(renames, model.PropertyRename{
+				Column: fields[i].Name,
+				From:   original,
+				To:     name,
+				Reason: reason,
+			})
+		}
+	}
+	return renames
+}
+
+// stripInvalidIdentifierChars 只保留 ASCII 字母、数字、下划线和 $
+func stripInvalidIdentifierChars(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '$' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))) {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r >= unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidIdentifier 判断名称能否直接用作属性名
+func isValidIdentifier(name string, lang model.Language) bool {
+	if name == "" || stripInvalidIdentifierChars(name) != name || javaKeywords[name] || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	return lang != model.LanguageKotlin || !kotlinKeywords[name]
+}
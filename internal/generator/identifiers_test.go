@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"testing"
+
+	"mybatis-plus-generator/internal/model"
+)
+
+func TestEscapeJavaIdentifiers(t *testing.T) {
+	tests := []struct {
+		column, property string
+		naming           model.NamingConfig
+		lang             model.Language
+		want, reason     string
+	}{
+		{"user_name", "userName", model.NamingConfig{}, model.LanguageJava, "userName", ""},
+		{"class", "class", model.NamingConfig{}, model.LanguageJava, "classField", "Java 关键字"},
+		{"class", "class", model.NamingConfig{PropertySuffix: "Value"}, model.LanguageJava, "classValue", "Java 关键字"},
+		{"2fa_code", "2faCode", model.NamingConfig{}, model.LanguageJava, "field2faCode", "以数字开头"},
+		{"2fa_code", "2faCode", model.NamingConfig{PropertyPrefix: "p"}, model.LanguageJava, "p2faCode", "以数字开头"},
+		{"名称", "", model.NamingConfig{}, model.LanguageJava, "field1", "包含非 ASCII 或非法字符"},
+		{"价格_cny", "cny", model.NamingConfig{}, model.LanguageJava, "cny", "包含非 ASCII 或非法字符"},
+		{"val", "val", model.NamingConfig{}, model.LanguageJava, "val", ""},
+		{"val", "val", model.NamingConfig{}, model.LanguageKotlin, "valField", "Kotlin 关键字"},
+	}
+	for _, tt := range tests {
+		fields := []model.Field{{Name: tt.column, PropertyName: tt.property}}
+		renames := escapeJavaIdentifiers(fields, tt.naming, tt.lang)
+		if got := fields[0].PropertyName; got != tt.want {
+			t.Errorf("%s PropertyName = %s, want %s", tt.column, got, tt.want)
+		}
+		if tt.reason == "" {
+			if len(renames) != 0 {
+				t.Errorf("%s renames = %+v, want none", tt.column, renames)
+			}
+			continue
+		}
+		if len(renames) != 1 || renames[0].Reason != tt.reason || renames[0].From != tt.property {
+			t.Errorf("%s renames = %+v, want reason %s", tt.column, renames, tt.reason)
+		}
+	}
+}
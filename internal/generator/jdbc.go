@@ -0,0 +1,68 @@
+package generator
+
+import "strings"
+
+// jdbcTypes 将 SQL 基础类型映射为 MyBatis 的 JdbcType
+var jdbcTypes = map[string]string{
+	"TINYINT":                     "TINYINT",
+	"SMALLINT":                    "SMALLINT",
+	"INT2":                        "SMALLINT",
+	"MEDIUMINT":                   "INTEGER",
+	"INT":                         "INTEGER",
+	"INTEGER":                     "INTEGER",
+	"INT4":                        "INTEGER",
+	"SERIAL":                      "INTEGER",
+	"BIGINT":                      "BIGINT",
+	"INT8":                        "BIGINT",
+	"BIGSERIAL":                   "BIGINT",
+	"DECIMAL":                     "DECIMAL",
+	"NUMERIC":                     "NUMERIC",
+	"FLOAT":                       "FLOAT",
+	"FLOAT4":                      "REAL",
+	"REAL":                        "REAL",
+	"DOUBLE":                      "DOUBLE",
+	"FLOAT8":                      "DOUBLE",
+	"DOUBLE PRECISION":            "DOUBLE",
+	"BIT":                         "BIT",
+	"BOOL":                        "BOOLEAN",
+	"BOOLEAN":                     "BOOLEAN",
+	"DATE":                        "DATE",
+	"TIME":                        "TIME",
+	"TIMETZ":                      "TIME",
+	"TIME WITHOUT TIME ZONE":      "TIME",
+	"TIME WITH TIME ZONE":         "TIME",
+	"DATETIME":                    "TIMESTAMP",
+	"TIMESTAMP":                   "TIMESTAMP",
+	"TIMESTAMPTZ":                 "TIMESTAMP",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
+	"TIMESTAMP WITH TIME ZONE":    "TIMESTAMP",
+	"CHAR":                        "CHAR",
+	"CHARACTER":                   "CHAR",
+	"BPCHAR":                      "CHAR",
+	"VARCHAR":                     "VARCHAR",
+	"CHARACTER VARYING":           "VARCHAR",
+	"TEXT":                        "LONGVARCHAR",
+	"TINYTEXT":                    "VARCHAR",
+	"MEDIUMTEXT":                  "LONGVARCHAR",
+	"LONGTEXT":                    "LONGVARCHAR",
+	"BINARY":                      "BINARY",
+	"VARBINARY":                   "VARBINARY",
+	"BLOB":                        "BLOB",
+	"MEDIUMBLOB":                  "LONGVARBINARY",
+	"LONGBLOB":                    "LONGVARBINARY",
+	"BYTEA":                       "BINARY",
+}
+
+// jdbcType 返回 SQL 类型对应的 JdbcType，无法识别时返回 OTHER
+func jdbcType(sqlType string) string {
+	upper := strings.ToUpper(strings.TrimSpace(sqlType))
+	if strings.HasPrefix(upper, "TINYINT(1)") {
+		return "BOOLEAN"
+	}
+	baseType := strings.TrimSpace(strings.Split(upper, "(")[0])
+	baseType = strings.TrimSuffix(baseType, " UNSIGNED")
+	if t, ok := jdbcTypes[baseType]; ok {
+		return t
+	}
+	return "OTHER"
+}
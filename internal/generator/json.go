@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"mybatis-plus-generator/internal/model"
+	"strings"
+)
+
+// jsonTypeHandlers 是各 ORM 基于 Jackson 的 JSON TypeHandler
+var jsonTypeHandlers = map[model.ORM]string{
+	model.ORMMyBatisPlus: "com.baomidou.mybatisplus.extension.handlers.JacksonTypeHandler",
+	model.ORMMyBatisFlex: "com.mybatisflex.core.handler.JacksonTypeHandler",
+}
+
+// jsonJavaTypes 是 JSON 列可选的 Java 类型，JSONTypeClass 的类型由列名推导
+var jsonJavaTypes = map[model.JSONType]string{
+	model.JSONTypeMap:      "Map<String, Object>",
+	model.JSONTypeJsonNode: "JsonNode",
+}
+
+// applyJSONTypes 将 JSON/JSONB 列映射为配置的 Java 类型并通过 JacksonTypeHandler 读写，返回需要在 DO 中生成的内部类
+// 内部类以 DO 类名限定 (如 OrderDO.Extra)，请求/响应对象可以直接引用
+func applyJSONTypes(fields []model.Field, doClassName string, paths model.PathConfig) []model.NestedClass {
+	handler := jsonTypeHandlers[paths.ORM]
+	if paths.JSONType == "" || handler == "" {
+		return nil
+	}
+
+	// 内部类与 DO 中使用的其他类型重名时会遮蔽外部类型，如 date -> Date
+	taken := toSet(doClassName)
+	for _, f := range fields {
+		taken[f.JavaType] = true
+	}
+
+	var nested []model.NestedClass
+	for i, f := range fields {
+		if f.IsId || !isJSONColumn(f.Type) {
+			continue
+		}
+		if paths.JSONType == model.JSONTypeClass {
+			name := capitalize(f.PropertyName)
+			if taken[name] {
+				name += "Json"
+			}
+			taken[name] = true
+			nested = append(nested, model.NestedClass{ClassName: name, Comment: f.Comment})
+			fields[i].JavaType = doClassName + "." + name
+		} else {
+			fields[i].JavaType = jsonJavaTypes[paths.JSONType]
+		}
+		fields[i].TypeHandler = handler
+	}
+	return nested
+}
+
+func isJSONColumn(sqlType string) bool {
+	baseType := strings.ToUpper(strings.TrimSpace(strings.Split(sqlType, "(")[0]))
+	return baseType == "JSON" || baseType == "JSONB"
+}
+
+// simpleClassName 返回全限定名中的类名
+func simpleClassName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"mybatis-plus-generator/internal/model"
+	"strings"
+)
+
+// kotlinTypes 是与 Kotlin 内置类型对应的 Java 类型，其余类型 (BigDecimal、java.time.* 等) 直接沿用
+var kotlinTypes = map[string]string{
+	"Integer":   "Int",
+	"Long":      "Long",
+	"Short":     "Short",
+	"Byte":      "Byte",
+	"Float":     "Float",
+	"Double":    "Double",
+	"Boolean":   "Boolean",
+	"Character": "Char",
+	"String":    "String",
+	"Object":    "Any",
+	"byte[]":    "ByteArray",
+	"List":      "List<Any>",
+	"Map":       "Map<String, Any>",
+	"Set":       "Set<Any>",
+}
+
+// kotlinBuiltinImports 是 Kotlin 中无需导入的 java.util 集合类型
+var kotlinBuiltinImports = toSet("java.util.List", "java.util.Map", "java.util.Set")
+
+// kotlinKeywords 是 Kotlin 中不能直接用作属性名的硬关键字 (Java 关键字之外的部分)
+var kotlinKeywords = toSet("as", "fun", "in", "is", "object", "typealias", "typeof", "val", "var", "when")
+
+// kotlinType 将 Java 类型转换为 Kotlin 类型，泛型参数会递归转换 (List<Integer> -> List<Int>)
+func kotlinType(javaType string) string {
+	javaType = strings.TrimSpace(javaType)
+	if t, ok := kotlinTypes[javaType]; ok {
+		return t
+	}
+	start, end := strings.Index(javaType, "<"), strings.LastIndex(javaType, ">")
+	if start > 0 && end > start {
+		args := strings.Split(javaType[start+1:end], ",")
+		for i, arg := range args {
+			args[i] = kotlinType(arg)
+		}
+		return javaType[:start] + "<" + strings.Join(args, ", ") + ">"
+	}
+	return javaType
+}
+
+// collectKotlinImports 收集 Kotlin DO 中字段类型所需的导入
+func collectKotlinImports(fields []model.Field) []string {
+	var imports []string
+	for _, imp := range collectImports(fields) {
+		if !kotlinBuiltinImports[imp] {
+			imports = append(imports, imp)
+		}
+	}
+	return imports
+}
@@ -0,0 +1,260 @@
+package generator
+
+import (
+	"fmt"
+	"mybatis-plus-generator/internal/model"
+	"sort"
+	"strings"
+)
+
+// annotationImports 是请求对象和 DO 中可能出现的注解及其导入
+var annotationImports = map[string]string{
+	"NotNull":        "jakarta.validation.constraints.NotNull",
+	"Size":           "jakarta.validation.constraints.Size",
+	"Digits":         "jakarta.validation.constraints.Digits",
+	"Min":            "jakarta.validation.constraints.Min",
+	"DateTimeFormat": "org.springframework.format.annotation.DateTimeFormat",
+
+	"Schema":           "io.swagger.v3.oas.annotations.media.Schema",
+	"ApiModel":         "io.swagger.annotations.ApiModel",
+	"ApiModelProperty": "io.swagger.annotations.ApiModelProperty",
+}
+
+// dateTimeFormats 是查询条件中时间范围字段的 @DateTimeFormat，用于绑定 GET 参数
+var dateTimeFormats = map[string]string{
+	"LocalDateTime": "DateTimeFormat(iso = DateTimeFormat.ISO.DATE_TIME)",
+	"LocalDate":     "DateTimeFormat(iso = DateTimeFormat.ISO.DATE)",
+	"LocalTime":     "DateTimeFormat(iso = DateTimeFormat.ISO.TIME)",
+	"Date":          `DateTimeFormat(pattern = "yyyy-MM-dd HH:mm:ss")`,
+}
+
+// modelClasses 为配置了路径的 CreateRequest、UpdateRequest、Query 和 VO 生成描述
+func modelClasses(data model.TemplateData, paths model.PathConfig) []model.ModelClass {
+	var classes []model.ModelClass
+	if paths.CreateRequestPath != "" {
+		classes = append(classes, createRequest(data, extractPackageName(paths.CreateRequestPath)))
+	}
+	if paths.UpdateRequestPath != "" {
+		classes = append(classes, updateRequest(data, extractPackageName(paths.UpdateRequestPath)))
+	}
+	if paths.QueryPath != "" {
+		classes = append(classes, queryModel(data, extractPackageName(paths.QueryPath)))
+	}
+	if paths.VOPath != "" {
+		classes = append(classes, voModel(data, extractPackageName(paths.VOPath)))
+	}
+	for i := range classes {
+		if paths.APIDoc != "" {
+			applyAPIDoc(&classes[i], paths.APIDoc)
+		}
+		classes[i].Imports = modelImports(classes[i], data.DO.Style)
+		for _, field := range classes[i].Fields {
+			if e, ok := findEnum(data.Enums, field.JavaType); ok && e.Package != classes[i].Package {
+				classes[i].Imports = javaLastImports(append(classes[i].Imports, e.Package+"."+e.ClassName))
+			}
+			// JSON 列的内部类以 DO 类名限定，需要导入 DO
+			if strings.HasPrefix(field.JavaType, data.DOClassName+".") && data.DOPackage != classes[i].Package {
+				classes[i].Imports = javaLastImports(append(classes[i].Imports, data.DOPackage+"."+data.DOClassName))
+			}
+		}
+	}
+	return classes
+}
+
+// modelPath 返回对应种类对象的输出目录
+func modelPath(paths model.PathConfig, kind model.ModelKind) string {
+	switch kind {
+	case model.ModelCreateRequest:
+		return paths.CreateRequestPath
+	case model.ModelUpdateRequest:
+		return paths.UpdateRequestPath
+	case model.ModelQuery:
+		return paths.QueryPath
+	default:
+		return paths.VOPath
+	}
+}
+
+// createRequest 不包含有默认值的主键及自动填充、逻辑删除、乐观锁、多租户字段，NOT NULL 且没有默认值的字段必填
+func createRequest(data model.TemplateData, pkg string) model.ModelClass {
+	class := model.ModelClass{Kind: model.ModelCreateRequest, ClassName: data.EntityName + "CreateRequest", Package: pkg, Comment: data.TableName + " 新增请求"}
+	for _, f := range data.Fields {
+		if (f.IsId && f.HasDefault) || f.Fill != "" || f.LogicDelete || f.Version || f.Tenant {
+			continue
+		}
+		field := modelField(f)
+		if f.NotNull && !f.HasDefault {
+			field.Annotations = append(field.Annotations, "NotNull")
+		}
+		field.Annotations = append(field.Annotations, sizeAnnotations(f)...)
+		class.Fields = append(class.Fields, field)
+	}
+	return class
+}
+
+// updateRequest 按主键更新，主键必填，其余字段为空表示不修改
+func updateRequest(data model.TemplateData, pkg string) model.ModelClass {
+	class := model.ModelClass{Kind: model.ModelUpdateRequest, ClassName: data.EntityName + "UpdateRequest", Package: pkg, Comment: data.TableName + " 修改请求"}
+	for _, f := range data.Fields {
+		if f.Fill != "" || f.LogicDelete || f.Tenant {
+			continue
+		}
+		field := modelField(f)
+		if f.IsId {
+			field.Annotations = append(field.Annotations, "NotNull")
+		}
+		field.Annotations = append(field.Annotations, sizeAnnotations(f)...)
+		class.Fields = append(class.Fields, field)
+	}
+	return class
+}
+
+// queryModel 包含分页参数，时间类型的字段拆分为起止范围，其余字段作为等值条件，JSON 列和租户列不作为条件
+func queryModel(data model.TemplateData, pkg string) model.ModelClass {
+	class := model.ModelClass{Kind: model.ModelQuery, ClassName: data.EntityName + "Query", Package: pkg, Comment: data.TableName + " 分页查询条件"}
+	class.Fields = append(class.Fields,
+		model.ModelField{Name: "pageNum", JavaType: "Integer", Comment: "页码，从 1 开始", Annotations: []string{"Min(1)"}, Default: "1"},
+		model.ModelField{Name: "pageSize", JavaType: "Integer", Comment: "每页条数", Annotations: []string{"Min(1)"}, Default: "10"},
+	)
+	for _, f := range data.Fields {
+		if f.LogicDelete || f.JavaType == "byte[]" || f.TypeHandler != "" || f.Tenant {
+			continue
+		}
+		format, temporal := dateTimeFormats[f.JavaType]
+		if !temporal {
+			class.Fields = append(class.Fields, modelField(f))
+			continue
+		}
+		comment := strings.TrimSpace(f.Comment)
+		if comment == "" {
+			comment = f.PropertyName + " "
+		}
+		for _, bound := range [][2]string{{"Start", "起始"}, {"End", "截止"}} {
+			class.Fields = append(class.Fields, model.ModelField{
+				Name:        f.PropertyName + bound[0],
+				JavaType:    f.JavaType,
+				Comment:     comment + bound[1],
+				Annotations: []string{format},
+				RangeOf:     f.PropertyName,
+			})
+		}
+	}
+	return class
+}
+
+// voModel 返回给前端的视图对象，不包含逻辑删除字段
+func voModel(data model.TemplateData, pkg string) model.ModelClass {
+	class := model.ModelClass{Kind: model.ModelVO, ClassName: data.EntityName + "VO", Package: pkg, Comment: data.TableName + " 视图对象"}
+	for _, f := range data.Fields {
+		if !f.LogicDelete {
+			class.Fields = append(class.Fields, modelField(f))
+		}
+	}
+	return class
+}
+
+// applyAPIDoc 为对象及其属性补充接口文档注解，带 @NotNull 的属性标记为必填
+func applyAPIDoc(class *model.ModelClass, style model.APIDocStyle) {
+	if annotation := apiDocClass(style, class.Comment); annotation != "" {
+		class.Annotations = append(class.Annotations, annotation)
+	}
+	for i, f := range class.Fields {
+		if annotation := apiDocField(style, f.Comment, f.JavaType, hasAnnotation(f.Annotations, "NotNull")); annotation != "" {
+			class.Fields[i].Annotations = append([]string{annotation}, f.Annotations...)
+		}
+	}
+}
+
+func modelField(f model.Field) model.ModelField {
+	return model.ModelField{Name: f.PropertyName, JavaType: f.JavaType, Comment: f.Comment}
+}
+
+// sizeAnnotations 根据字符长度和数值精度生成 @Size、@Digits
+func sizeAnnotations(f model.Field) []string {
+	var annotations []string
+	if f.JavaType == "String" && f.Length > 0 {
+		annotations = append(annotations, fmt.Sprintf("Size(max = %d)", f.Length))
+	}
+	if f.JavaType == "BigDecimal" && f.Precision > 0 {
+		annotations = append(annotations, fmt.Sprintf("Digits(integer = %d, fraction = %d)", f.Precision-f.Scale, f.Scale))
+	}
+	return annotations
+}
+
+// modelImports 收集对象中注解和字段类型所需的导入，java.* 排在最后
+func modelImports(class model.ModelClass, style model.DOStyle) []string {
+	var imports []string
+	if style == model.DOStyleLombok {
+		imports = append(imports, "lombok.Data")
+	}
+	for _, annotation := range class.Annotations {
+		imports = append(imports, annotationImports[annotationName(annotation)])
+	}
+	for _, f := range class.Fields {
+		for _, annotation := range f.Annotations {
+			imports = append(imports, annotationImports[annotationName(annotation)])
+		}
+		if imp := getJavaTypeImport(f.JavaType); imp != "" {
+			imports = append(imports, imp)
+		}
+	}
+	return javaLastImports(imports)
+}
+
+// javaLastImports 去重并排序导入，java.* 排在最后
+func javaLastImports(imports []string) []string {
+	imports = mergeImports(nil, imports...)
+	sort.SliceStable(imports, func(i, j int) bool {
+		return !strings.HasPrefix(imports[i], "java.") && strings.HasPrefix(imports[j], "java.")
+	})
+	return imports
+}
+
+// converterData 计算 MapStruct 转换器的方法和忽略的属性，DO 中不存在于请求对象的属性 (自增主键、审计、逻辑删除、乐观锁) 均显式忽略
+func converterData(data model.TemplateData, paths model.PathConfig, baseName string) *model.ConverterData {
+	converter := &model.ConverterData{
+		ClassName: className(paths.Naming.ConverterPattern, defaultConverterPattern, baseName),
+		Package:   extractPackageName(paths.ConverterPath),
+	}
+	imports := []string{"org.mapstruct.Mapper", "java.util.List", data.DOPackage + "." + data.DOClassName}
+	for _, class := range data.Models {
+		switch class.Kind {
+		case model.ModelVO:
+			converter.VOClass = class.ClassName
+		case model.ModelCreateRequest:
+			converter.CreateClass = class.ClassName
+			converter.CreateIgnores = missingProperties(data.Fields, class, false)
+		case model.ModelUpdateRequest:
+			converter.UpdateClass = class.ClassName
+			// 主键只用于定位记录，不覆盖到 DO 上
+			converter.UpdateIgnores = missingProperties(data.Fields, class, true)
+			imports = append(imports, "org.mapstruct.BeanMapping", "org.mapstruct.MappingTarget", "org.mapstruct.NullValuePropertyMappingStrategy")
+		case model.ModelQuery:
+			converter.QueryClass = class.ClassName
+			converter.QueryIgnores = missingProperties(data.Fields, class, false)
+		default:
+			continue
+		}
+		imports = append(imports, class.Package+"."+class.ClassName)
+	}
+	if len(converter.CreateIgnores) > 0 || len(converter.UpdateIgnores) > 0 || len(converter.QueryIgnores) > 0 {
+		imports = append(imports, "org.mapstruct.Mapping")
+	}
+	converter.Imports = javaLastImports(imports)
+	return converter
+}
+
+// missingProperties 返回 DO 中没有出现在请求对象里的属性
+func missingProperties(fields []model.Field, class model.ModelClass, ignoreId bool) []string {
+	present := make(map[string]bool, len(class.Fields))
+	for _, f := range class.Fields {
+		present[f.Name] = true
+	}
+	var missing []string
+	for _, f := range fields {
+		if !present[f.PropertyName] || (ignoreId && f.IsId) {
+			missing = append(missing, f.PropertyName)
+		}
+	}
+	return missing
+}
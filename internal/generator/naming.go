@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"fmt"
+	"mybatis-plus-generator/internal/model"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+)
+
+// 类名模式的默认值，与之前硬编码的命名保持一致
+const (
+	defaultDOPattern      = "{{Camel}}DO"
+	defaultMapperPattern  = "{{Camel}}Mapper"
+	defaultDAOPattern     = "{{Camel}}DAO"
+	defaultDAOImplPattern = "{{Camel}}DAOImpl"
+
+	defaultServicePattern     = "{{Camel}}Service"
+	defaultServiceImplPattern = "{{Camel}}ServiceImpl"
+	defaultControllerPattern  = "{{Camel}}Controller"
+	defaultConverterPattern   = "{{Camel}}Converter"
+
+	// JPA 下 Mapper 的位置生成的是 Spring Data Repository
+	defaultRepositoryPattern = "{{Camel}}Repository"
+)
+
+// stripTableName 按配置去除表名的前缀、后缀以及正则匹配到的部分
+func stripTableName(tableName string, naming model.NamingConfig) string {
+	name := tableName
+	for _, prefix := range naming.TablePrefixes {
+		if prefix != "" && strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	for _, suffix := range naming.TableSuffixes {
+		if suffix != "" && strings.HasSuffix(strings.ToLower(name), strings.ToLower(suffix)) {
+			name = name[:len(name)-len(suffix)]
+			break
+		}
+	}
+	if naming.TableRegex != "" {
+		// 正则在读取请求时已经校验过，这里编译失败时直接忽略
+		if re, err := regexp.Compile(naming.TableRegex); err == nil {
+			name = re.ReplaceAllString(name, "")
+		}
+	}
+
+	// 去除后为空时退回原始表名，避免生成空类名
+	if strings.Trim(name, "_") == "" {
+		return tableName
+	}
+	return name
+}
+
+// className 用表名替换类名模式中的占位符
+// 支持 {{Camel}}(大驼峰)、{{camel}}(小驼峰) 和 {{Table}}(去除前后缀后的原始表名)
+func className(pattern, defaultPattern, baseName string) string {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = defaultPattern
+	}
+	replacer := strings.NewReplacer(
+		"{{Camel}}", strcase.ToCamel(baseName),
+		"{{camel}}", strcase.ToLowerCamel(baseName),
+		"{{Table}}", baseName,
+	)
+	return replacer.Replace(strings.TrimSpace(pattern))
+}
+
+// decapitalize 将类名首字母小写作为变量名，如 OrderMapper -> orderMapper
+func decapitalize(name string) string {
+	runes := []rune(name)
+	if len(runes) == 0 {
+		return name
+	}
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// dedupeProperties 处理重复的属性名 (不区分大小写)，并把改名记录合并到 renames 中
+// 由列名直接转换得到的属性优先保留原名；去除了 is 或列名前缀的属性恢复为完整列名的驼峰形式
+// (同时存在 is_deleted 和 deleted 时为 isDeleted)，仍然重复时追加序号，如 userName2
+func dedupeProperties(fields []model.Field, renames []model.PropertyRename, lang model.Language) []model.PropertyRename {
+	natural := make([]bool, len(fields))
+	for i, f := range fields {
+		natural[i] = f.PropertyName == strcase.ToLowerCamel(f.Name)
+	}
+
+	taken := make(map[string]bool, len(fields))
+	conflicts := make([]bool, len(fields))
+	for _, claimNatural := range []bool{true, false} {
+		for i, f := range fields {
+			if natural[i] != claimNatural {
+				continue
+			}
+			key := strings.ToLower(f.PropertyName)
+			conflicts[i] = taken[key]
+			taken[key] = true
+		}
+	}
+
+	for i := range fields {
+		if !conflicts[i] {
+			continue
+		}
+		original := fields[i].PropertyName
+		name := strcase.ToLowerCamel(fields[i].Name)
+		if natural[i] || !isValidIdentifier(name, lang) || taken[strings.ToLower(name)] {
+			n := 2
+			for taken[strings.ToLower(fmt.Sprintf("%s%d", original, n))] {
+				n++
+			}
+			name = fmt.Sprintf("%s%d", original, n)
+		}
+		taken[strings.ToLower(name)] = true
+		fields[i].PropertyName = name
+
+		// 已因非法标识符改过名的列只保留一条记录
+		merged := false
+		for j := range renames {
+			if renames[j].Column == fields[i].Name {
+				renames[j].To, renames[j].Reason = name, renames[j].Reason+"，且与其他列的属性重名"
+				merged = true
+			}
+		}
+		if !merged {
+			renames = append(renames, model.PropertyRename{Column: fields[i].Name, From: original, To: name, Reason: "与其他列的属性重名"})
+		}
+	}
+	return renames
+}
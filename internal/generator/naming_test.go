@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"testing"
+
+	"mybatis-plus-generator/internal/model"
+)
+
+func TestDedupeProperties(t *testing.T) {
+	fields := []model.Field{
+		{Name: "is_deleted", PropertyName: "deleted"},
+		{Name: "deleted", PropertyName: "deleted"},
+		{Name: "user_name", PropertyName: "userName"},
+		{Name: "userName", PropertyName: "userName"},
+		{Name: "f_code", PropertyName: "code"},
+		{Name: "code", PropertyName: "code"},
+		{Name: "fCode", PropertyName: "fCode"},
+		{Name: "class", PropertyName: "classField"},
+		{Name: "class_field", PropertyName: "classField"},
+	}
+	renames := []model.PropertyRename{{Column: "class", From: "class", To: "classField", Reason: "Java 关键字"}}
+	renames = dedupeProperties(fields, renames, model.LanguageJava)
+
+	want := []string{"isDeleted", "deleted", "userName", "userName2", "code2", "code", "fCode", "classField2", "classField"}
+	for i, f := range fields {
+		if f.PropertyName != want[i] {
+			t.Errorf("%s PropertyName = %s, want %s", f.Name, f.PropertyName, want[i])
+		}
+	}
+
+	byColumn := make(map[string]model.PropertyRename)
+	for _, r := range renames {
+		byColumn[r.Column] = r
+	}
+	if len(renames) != 4 {
+		t.Fatalf("renames = %+v, want 4 entries", renames)
+	}
+	if r := byColumn["is_deleted"]; r.From != "deleted" || r.To != "isDeleted" || r.Reason != "与其他列的属性重名" {
+		t.Errorf("is_deleted rename = %+v", r)
+	}
+	if r := byColumn["class"]; r.From != "class" || r.To != "classField2" || r.Reason != "Java 关键字，且与其他列的属性重名" {
+		t.Errorf("class rename = %+v", r)
+	}
+}
@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"fmt"
+	"mybatis-plus-generator/internal/model"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// openAPITypes 是 Java 类型对应的 OpenAPI type 和 format，未列出的类型按字符串处理
+var openAPITypes = map[string][2]string{
+	"Integer":       {"integer", "int32"},
+	"Short":         {"integer", "int32"},
+	"Byte":          {"integer", "int32"},
+	"Long":          {"integer", "int64"},
+	"BigInteger":    {"integer", ""},
+	"BigDecimal":    {"number", ""},
+	"Double":        {"number", "double"},
+	"Float":         {"number", "float"},
+	"Boolean":       {"boolean", ""},
+	"LocalDate":     {"string", "date"},
+	"LocalDateTime": {"string", "date-time"},
+	"Date":          {"string", "date-time"},
+	"LocalTime":     {"string", "time"},
+	"Duration":      {"string", "duration"},
+	"UUID":          {"string", "uuid"},
+	"byte[]":        {"string", "byte"},
+}
+
+// openAPIPage 描述分页结果序列化后的结构
+type openAPIPage struct {
+	records string
+	counts  []string
+}
+
+// openAPIPages 是各 ORM 的 Service 分页结果 (见 pageTypes) 序列化后的属性
+var openAPIPages = map[model.ORM]openAPIPage{
+	model.ORMMyBatisPlus: {"records", []string{"current", "size", "total", "pages"}},
+	model.ORMMyBatisFlex: {"records", []string{"pageNumber", "pageSize", "totalRow", "totalPage"}},
+	model.ORMMyBatis:     {"list", []string{"pageNum", "pageSize", "total", "pages"}},
+	model.ORMTkMybatis:   {"list", []string{"pageNum", "pageSize", "total", "pages"}},
+	model.ORMDynamicSQL:  {"list", []string{"pageNum", "pageSize", "total", "pages"}},
+	model.ORMJPA:         {"content", []string{"number", "size", "totalElements", "totalPages"}},
+}
+
+// openAPIData 推导接口用到的 schema，路径及请求/响应类型与生成的 Controller 一致：
+// 配置了转换器时使用请求/响应对象，否则直接使用 DO
+func openAPIData(data model.TemplateData, paths model.PathConfig) *model.OpenAPIData {
+	title := strings.TrimSpace(data.TableComment)
+	if title == "" {
+		title = data.TableName
+	}
+	page := openAPIPages[paths.ORM]
+	spec := &model.OpenAPIData{
+		FileName:     strcase.ToKebab(data.EntityName) + ".openapi.yaml",
+		Title:        title,
+		RequestPath:  requestPath(data, paths.Web),
+		CreateSchema: data.DOClassName,
+		UpdateSchema: data.DOClassName,
+		ViewSchema:   data.DOClassName,
+		PageRecords:  page.records,
+		PageCounts:   page.counts,
+	}
+	var query *model.ModelClass
+	var models []model.OpenAPISchema
+	if data.Converter != nil {
+		for i, class := range data.Models {
+			switch class.Kind {
+			case model.ModelCreateRequest:
+				spec.CreateSchema = class.ClassName
+			case model.ModelUpdateRequest:
+				spec.UpdateSchema = class.ClassName
+			case model.ModelVO:
+				spec.ViewSchema = class.ClassName
+			case model.ModelQuery:
+				query = &data.Models[i]
+				continue
+			}
+			models = append(models, openAPIModelSchema(class, data))
+		}
+	}
+	spec.PageSchema = spec.ViewSchema + "Page"
+
+	entity := model.OpenAPISchema{Name: data.DOClassName}
+	for _, f := range data.Fields {
+		property := openAPIProperty(f, data.Enums)
+		entity.Properties = append(entity.Properties, property)
+		// 有默认值的列 (自增主键、DEFAULT) 和由租户插件填充的租户列新增时可以不传
+		if f.NotNull && !f.HasDefault && !f.Tenant {
+			entity.Required = append(entity.Required, property.Name)
+		}
+		if query == nil && property.Format != "byte" && property.Type != "array" && property.Type != "object" && !f.LogicDelete && !f.Tenant {
+			spec.QueryParams = append(spec.QueryParams, property)
+		}
+	}
+	if spec.CreateSchema == data.DOClassName || spec.UpdateSchema == data.DOClassName || spec.ViewSchema == data.DOClassName {
+		spec.Schemas = append(spec.Schemas, entity)
+	}
+	spec.Schemas = append(spec.Schemas, models...)
+	if query != nil {
+		// 分页参数由模板固定生成
+		for _, property := range openAPIModelSchema(*query, data).Properties {
+			if property.Name != "pageNum" && property.Name != "pageSize" {
+				spec.QueryParams = append(spec.QueryParams, property)
+			}
+		}
+	}
+	if len(data.IdFields) == 1 {
+		id := openAPIProperty(data.IdFields[0], nil)
+		spec.Id = &id
+	}
+	if resultClass := paths.Web.ResultClass; resultClass != "" {
+		spec.ResultClass = resultClass
+		spec.ResultSchema = simpleClassName(resultClass)
+	}
+	return spec
+}
+
+// openAPIModelSchema 由请求/响应对象推导 schema，属性沿用 DO 字段的类型和长度，带 @NotNull 的属性必填
+func openAPIModelSchema(class model.ModelClass, data model.TemplateData) model.OpenAPISchema {
+	fields := make(map[string]model.Field, len(data.Fields))
+	for _, f := range data.Fields {
+		fields[f.PropertyName] = f
+	}
+	schema := model.OpenAPISchema{Name: class.ClassName}
+	for _, mf := range class.Fields {
+		f, ok := fields[mf.RangeOf]
+		if !ok {
+			f, ok = fields[mf.Name]
+		}
+		if !ok {
+			f = model.Field{JavaType: mf.JavaType}
+		}
+		property := openAPIProperty(f, data.Enums)
+		property.Name, property.Description = mf.Name, strings.TrimSpace(mf.Comment)
+		schema.Properties = append(schema.Properties, property)
+		if hasAnnotation(mf.Annotations, "NotNull") {
+			schema.Required = append(schema.Required, mf.Name)
+		}
+	}
+	return schema
+}
+
+// openAPIProperty 由字段推导属性，枚举按 Jackson 默认的序列化方式描述为常量名
+func openAPIProperty(f model.Field, enums []model.EnumClass) model.OpenAPIProperty {
+	property := model.OpenAPIProperty{Name: f.PropertyName, Type: "string", Description: strings.TrimSpace(f.Comment)}
+	if t, ok := openAPITypes[f.JavaType]; ok {
+		property.Type, property.Format = t[0], t[1]
+	}
+	if f.JavaType == "List" || strings.HasPrefix(f.JavaType, "List<") {
+		property.Type, property.Items = "array", "string"
+	}
+	if f.TypeHandler != "" {
+		property.Type = "object"
+	}
+	if e, ok := findEnum(enums, f.JavaType); ok {
+		for _, c := range e.Constants {
+			property.Enum = append(property.Enum, c.Name)
+		}
+	}
+	if property.Type == "string" && property.Format == "" && len(property.Enum) == 0 && f.Length > 0 {
+		property.MaxLength = f.Length
+	}
+	return property
+}
+
+// openAPISchema 将属性渲染为缩进 indent 个空格的 schema 内容 (不以换行结尾)
+// 用法: {{openAPISchema 10 .}}
+func openAPISchema(indent int, p model.OpenAPIProperty) string {
+	lines := []string{"type: " + p.Type}
+	if p.Format != "" {
+		lines = append(lines, "format: "+p.Format)
+	}
+	if p.Items != "" {
+		lines = append(lines, "items:", "  type: "+p.Items)
+	}
+	if len(p.Enum) > 0 {
+		lines = append(lines, "enum:")
+		for _, value := range p.Enum {
+			lines = append(lines, "  - "+yamlString(value))
+		}
+	}
+	if p.MaxLength > 0 {
+		lines = append(lines, fmt.Sprintf("maxLength: %d", p.MaxLength))
+	}
+	if p.Description != "" {
+		lines = append(lines, "description: "+yamlString(p.Description))
+	}
+	return indentLines(indent, lines)
+}
+
+// openAPIResponse 渲染缩进 indent 个空格的响应 schema (不以换行结尾)，schema 为空表示返回 boolean，
+// 配置了响应包装类时通过 allOf 将数据放在包装类的 data 属性中
+// 用法: {{openAPIResponse 16 $result $schema}}
+func openAPIResponse(indent int, result, schema string) string {
+	payload := "type: boolean"
+	if schema != "" {
+		payload = "$ref: '#/components/schemas/" + schema + "'"
+	}
+	lines := []string{payload}
+	if result != "" {
+		lines = []string{
+			"allOf:",
+			"  - $ref: '#/components/schemas/" + result + "'",
+			"  - type: object",
+			"    properties:",
+			"      data:",
+			"        " + payload,
+		}
+	}
+	return indentLines(indent, lines)
+}
+
+func indentLines(indent int, lines []string) string {
+	pad := strings.Repeat(" ", indent)
+	return pad + strings.Join(lines, "\n"+pad)
+}
+
+// yamlString 将字符串渲染为 YAML 双引号标量，Go 的转义序列与 YAML 兼容
+func yamlString(s string) string {
+	return strconv.Quote(s)
+}
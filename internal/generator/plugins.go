@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"embed"
+	"mybatis-plus-generator/internal/model"
+	"path/filepath"
+	"strings"
+)
+
+// pluginConfigClassName 是生成的 MyBatis-Plus 插件配置类名，整个项目只应有一个 MybatisPlusInterceptor
+const pluginConfigClassName = "MybatisPlusConfig"
+
+// paginationDbTypes 是各方言对应的 MyBatis-Plus DbType
+var paginationDbTypes = map[string]string{
+	"mysql":      "MYSQL",
+	"postgresql": "POSTGRE_SQL",
+	"postgres":   "POSTGRE_SQL",
+}
+
+// GeneratePluginConfig 汇总同批生成的表，生成注册租户、动态表名、分页和乐观锁插件的 MybatisPlusConfig，
+// 放在 DAO 目录的 config 子包下；租户插件忽略的表只包含本批中没有租户列的表
+func GeneratePluginConfig(datas []model.TemplateData, tables []model.TableInfo, paths model.PathConfig, templatesFS embed.FS) error {
+	if !paths.PluginConfig || paths.ORM != model.ORMMyBatisPlus || len(datas) == 0 {
+		return nil
+	}
+	ext := ".java"
+	if paths.Language == model.LanguageKotlin {
+		ext = ".kt"
+	}
+	cfg := pluginConfigData(datas, tables, paths)
+	templateName := model.Path(paths.ORM, paths.Language) + "/plugin_config.tmpl"
+	return renderTemplate(templatesFS, templateName, cfg, filepath.Join(paths.DAOPath, "config", pluginConfigClassName+ext))
+}
+
+// pluginConfigData 由各表的租户列、乐观锁字段、分表和方言推导插件配置
+func pluginConfigData(datas []model.TemplateData, tables []model.TableInfo, paths model.PathConfig) model.PluginConfigData {
+	cfg := model.PluginConfigData{
+		Package: datas[0].DAOPackage + ".config",
+		DbType:  paginationDbTypes[strings.ToLower(tables[0].DbType)],
+	}
+	if cfg.DbType == "" {
+		cfg.DbType = "OTHER"
+	}
+	var ignoreTables []string
+	for _, data := range datas {
+		tenant := false
+		for _, f := range data.Fields {
+			cfg.OptimisticLocker = cfg.OptimisticLocker || f.Version
+			if f.Tenant {
+				tenant = true
+				if cfg.TenantType == "" {
+					cfg.TenantType = tenantType(f.JavaType)
+				}
+			}
+		}
+		if !tenant {
+			ignoreTables = append(ignoreTables, strings.ToLower(data.TableName))
+		}
+	}
+	for _, table := range tables {
+		cfg.DynamicTableName = cfg.DynamicTableName || (table.Shard != nil && paths.ShardRule == model.ShardRuleDynamicTable)
+	}
+	if cfg.TenantType != "" {
+		cfg.TenantColumn = paths.ColumnRules.Tenant
+		cfg.IgnoreTables = ignoreTables
+	}
+	return cfg
+}
+
+// tenantType 返回租户 ID 的类型，整数对应 jsqlparser 的 LongValue，其余类型按字符串 (StringValue) 处理
+func tenantType(javaType string) string {
+	switch javaType {
+	case "Long", "Integer", "Short":
+		return javaType
+	}
+	return "String"
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"embed"
+	"mybatis-plus-generator/internal/model"
+	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// pageTypes 是各 ORM 的 Service 分页查询返回的类型及其导入，原生 MyBatis、tk.mybatis 和 Dynamic SQL 通过 PageHelper 分页
+var pageTypes = map[model.ORM][2]string{
+	model.ORMMyBatisPlus: {"IPage", "com.baomidou.mybatisplus.core.metadata.IPage"},
+	model.ORMMyBatisFlex: {"Page", "com.mybatisflex.core.paginate.Page"},
+	model.ORMMyBatis:     {"PageInfo", "com.github.pagehelper.PageInfo"},
+	model.ORMTkMybatis:   {"PageInfo", "com.github.pagehelper.PageInfo"},
+	model.ORMDynamicSQL:  {"PageInfo", "com.github.pagehelper.PageInfo"},
+	model.ORMJPA:         {"Page", "org.springframework.data.domain.Page"},
+}
+
+// serviceData 计算 Service、ServiceImpl 和 Controller 的类名、包名及响应包装方式
+func serviceData(data model.TemplateData, paths model.PathConfig, baseName string) *model.ServiceData {
+	naming := paths.Naming
+	serviceClassName := className(naming.ServicePattern, defaultServicePattern, baseName)
+	page := pageTypes[paths.ORM]
+	service := &model.ServiceData{
+		ServiceClassName:     serviceClassName,
+		ServiceImplClassName: className(naming.ServiceImplPattern, defaultServiceImplPattern, baseName),
+		ControllerClassName:  className(naming.ControllerPattern, defaultControllerPattern, baseName),
+		ServiceVarName:       decapitalize(serviceClassName),
+		DAOVarName:           decapitalize(data.DAOClassName),
+		ServicePackage:       extractPackageName(paths.ServicePath),
+		ServiceImplPackage:   extractPackageName(paths.ServiceImplPath),
+		ControllerPackage:    extractPackageName(paths.ControllerPath),
+		PageType:             page[0],
+		PageImport:           page[1],
+		RequestPath:          requestPath(data, paths.Web),
+	}
+	if resultClass := paths.Web.ResultClass; resultClass != "" {
+		service.ResultImport = resultClass
+		service.ResultClassName = simpleClassName(resultClass)
+		service.ResultMethod = paths.Web.ResultMethod
+		if service.ResultMethod == "" {
+			service.ResultMethod = "success"
+		}
+	}
+	if data.Converter != nil {
+		applyServiceModels(service, data)
+	}
+	return service
+}
+
+// applyServiceModels 让 Service/Controller 通过转换器读写请求/响应对象，客户端因此无法直接写入主键、租户、逻辑删除等由服务端维护的字段
+func applyServiceModels(service *model.ServiceData, data model.TemplateData) {
+	converter := data.Converter
+	service.ConverterClassName = converter.ClassName
+	service.ConverterVarName = decapitalize(converter.ClassName)
+	service.CreateClass = converter.CreateClass
+	service.UpdateClass = converter.UpdateClass
+	service.QueryClass = converter.QueryClass
+	service.VOClass = converter.VOClass
+	service.ConverterImport = converter.Package + "." + converter.ClassName
+	for _, class := range data.Models {
+		service.ModelImports = append(service.ModelImports, class.Package+"."+class.ClassName)
+		if class.Kind == model.ModelQuery {
+			service.Ranges = queryRanges(class)
+		}
+	}
+	service.ModelImports = javaLastImports(service.ModelImports)
+	if len(data.IdFields) == 1 {
+		service.IdProperty = data.IdFields[0].PropertyName
+	}
+}
+
+// queryRanges 收集 Query 中的时间范围，queryModel 总是连续生成起始和截止属性
+func queryRanges(class model.ModelClass) []model.QueryRange {
+	var ranges []model.QueryRange
+	for i := 0; i+1 < len(class.Fields); i++ {
+		if start := class.Fields[i]; start.RangeOf != "" {
+			ranges = append(ranges, model.QueryRange{Property: start.RangeOf, Start: start.Name, End: class.Fields[i+1].Name})
+			i++
+		}
+	}
+	return ranges
+}
+
+// requestPath 返回 Controller 的 @RequestMapping 路径，如 /api/order-item
+func requestPath(data model.TemplateData, web model.WebConfig) string {
+	path := strings.TrimRight(web.URLPrefix, "/") + "/" + strcase.ToKebab(data.EntityName)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// generateServiceFiles 生成 Service 接口、各 ORM 对应的 ServiceImpl，以及配置了路径时的 Controller
+func generateServiceFiles(data model.TemplateData, paths model.PathConfig, pathPrefix string, templatesFS embed.FS) error {
+	service := data.Service
+	templateMappings := map[string]string{
+		serviceTemplates + "/service.tmpl": filepath.Join(paths.ServicePath, service.ServiceClassName+".java"),
+		pathPrefix + "/service_impl.tmpl":  filepath.Join(paths.ServiceImplPath, service.ServiceImplClassName+".java"),
+	}
+	if paths.ControllerPath != "" {
+		templateMappings[serviceTemplates+"/controller.tmpl"] = filepath.Join(paths.ControllerPath, service.ControllerClassName+".java")
+	}
+	for templateName, outputPath := range templateMappings {
+		if err := renderTemplate(templatesFS, templateName, data, outputPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package generator
+
+import (
+	"embed"
+	"fmt"
+	"mybatis-plus-generator/internal/model"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// shardSuffix 匹配以下划线和数字结尾的表名，如 order_0、log_202401
+var shardSuffix = regexp.MustCompile(`^(.+)_(\d+)$`)
+
+// shardDateFormats 是日期后缀的格式，unit 为 ShardingSphere INTERVAL 算法的时间间隔单位
+var shardDateFormats = []struct {
+	java, layout, unit string
+}{
+	{"yyyyMM", "200601", "MONTHS"},
+	{"yyyyMMdd", "20060102", "DAYS"},
+}
+
+// shardingTemplate 是 ShardingSphere-JDBC 分片规则模板
+const shardingTemplate = "templates/sharding/sharding.yaml.tmpl"
+
+// shardTableNameHandlerClassName 是生成的动态表名处理器类名
+const shardTableNameHandlerClassName = "ShardTableNameHandler"
+
+// MergeShardTables 将列名和类型都相同、仅数字或日期后缀不同的至少两张表合并为一个逻辑表，
+// 逻辑表使用第一张分表的列和注释；逻辑表名与批次中已有的表重名时不合并，引用分表的外键改为引用逻辑表
+func MergeShardTables(tables []model.TableInfo) []model.TableInfo {
+	type group struct {
+		logic   string
+		indexes []int
+	}
+	var groups []*group
+	bySignature := make(map[string]*group)
+	existing := make(map[string]bool, len(tables))
+	for i, table := range tables {
+		existing[strings.ToLower(table.TableName)] = true
+		m := shardSuffix.FindStringSubmatch(table.TableName)
+		if m == nil {
+			continue
+		}
+		key := strings.ToLower(m[1]) + "\x00" + columnSignature(table.Fields)
+		g, ok := bySignature[key]
+		if !ok {
+			g = &group{logic: m[1]}
+			bySignature[key] = g
+			groups = append(groups, g)
+		}
+		g.indexes = append(g.indexes, i)
+	}
+
+	families := make(map[int]*model.ShardFamily)
+	merged := make(map[int]bool)
+	renames := make(map[string]string)
+	for _, g := range groups {
+		if len(g.indexes) < 2 || existing[strings.ToLower(g.logic)] {
+			continue
+		}
+		// 列不同的另一组同名分表不再合并，避免生成两个同名的逻辑表
+		existing[strings.ToLower(g.logic)] = true
+		suffixes := make([]string, len(g.indexes))
+		for j, i := range g.indexes {
+			suffixes[j] = tables[i].TableName[len(g.logic)+1:]
+			merged[i] = true
+			renames[strings.ToLower(tables[i].TableName)] = g.logic
+		}
+		families[g.indexes[0]] = shardFamily(g.logic, suffixes)
+	}
+	if len(families) == 0 {
+		return tables
+	}
+
+	result := make([]model.TableInfo, 0, len(tables)-len(merged)+len(families))
+	for i, table := range tables {
+		if family, ok := families[i]; ok {
+			table.TableName, table.Shard = family.LogicTable, family
+		} else if merged[i] {
+			continue
+		}
+		if len(table.ForeignKeys) > 0 {
+			foreignKeys := make([]model.ForeignKey, len(table.ForeignKeys))
+			for j, fk := range table.ForeignKeys {
+				if logic, ok := renames[strings.ToLower(fk.RefTable)]; ok {
+					fk.RefTable = logic
+				}
+				foreignKeys[j] = fk
+			}
+			table.ForeignKeys = foreignKeys
+		}
+		result = append(result, table)
+	}
+	return result
+}
+
+// shardFamily 按数值升序排列后缀，后缀都是同一格式的合法日期时按日期分表处理
+func shardFamily(logic string, suffixes []string) *model.ShardFamily {
+	sort.Slice(suffixes, func(i, j int) bool {
+		if len(suffixes[i]) != len(suffixes[j]) {
+			return len(suffixes[i]) < len(suffixes[j])
+		}
+		return suffixes[i] < suffixes[j]
+	})
+	family := &model.ShardFamily{LogicTable: logic, Suffixes: suffixes}
+	for _, format := range shardDateFormats {
+		if isDateSuffixes(suffixes, format.layout) {
+			family.DateFormat = format.java
+			break
+		}
+	}
+	return family
+}
+
+func isDateSuffixes(suffixes []string, layout string) bool {
+	for _, s := range suffixes {
+		if len(s) != len(layout) {
+			return false
+		}
+		if _, err := time.Parse(layout, s); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func columnSignature(fields []model.Field) string {
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = strings.ToLower(f.Name) + " " + strings.ToUpper(f.Type)
+	}
+	return strings.Join(columns, ",")
+}
+
+// shardTable 是一个逻辑表的分表路由配置
+type shardTable struct {
+	LogicTable      string
+	Range           string // 后缀范围，如 0 ~ 63、202401 ~ 202412 (yyyyMM)
+	ActualDataNodes string
+	ShardingColumn  string
+	Algorithm       string
+	AlgorithmType   string
+	Props           [][2]string // 分片算法的属性，值已渲染为 YAML 标量
+	Note            string      // 需要按实际情况确认的配置
+}
+
+// shardingData 是分片规则和动态表名处理器模板的数据
+type shardingData struct {
+	Package string
+	Tables  []shardTable
+}
+
+// GenerateShardRules 为合并后的分表生成 ShardingSphere 分片规则 sharding.yaml，
+// 或放在 DAO 目录 config 子包下的 MyBatis-Plus 动态表名处理器
+func GenerateShardRules(datas []model.TemplateData, tables []model.TableInfo, paths model.PathConfig, templatesFS embed.FS) error {
+	var sharding shardingData
+	for i, table := range tables {
+		if table.Shard != nil {
+			sharding.Tables = append(sharding.Tables, newShardTable(*table.Shard, datas[i]))
+		}
+	}
+	if len(sharding.Tables) == 0 {
+		return nil
+	}
+	switch paths.ShardRule {
+	case model.ShardRuleShardingSphere:
+		return renderTemplate(templatesFS, shardingTemplate, sharding, filepath.Join(paths.ShardingPath, "sharding.yaml"))
+	case model.ShardRuleDynamicTable:
+		sharding.Package = datas[0].DAOPackage + ".config"
+		templateName := model.Path(paths.ORM, paths.Language) + "/shard_table_name_handler.tmpl"
+		return renderTemplate(templatesFS, templateName, sharding, filepath.Join(paths.DAOPath, "config", shardTableNameHandlerClassName+".java"))
+	}
+	return nil
+}
+
+// newShardTable 推导分片规则：数字后缀按主键取模 (INLINE)，日期后缀按时间列分片 (INTERVAL)，数据源统一为 ds_0
+func newShardTable(family model.ShardFamily, data model.TemplateData) shardTable {
+	logic := family.LogicTable
+	first, last := family.Suffixes[0], family.Suffixes[len(family.Suffixes)-1]
+	t := shardTable{LogicTable: logic, Range: first + " ~ " + last}
+
+	quoted := make([]string, len(family.Suffixes))
+	for i, s := range family.Suffixes {
+		quoted[i] = "'" + s + "'"
+	}
+	t.ActualDataNodes = "ds_0." + logic + "_${[" + strings.Join(quoted, ", ") + "]}"
+
+	var notes []string
+	if family.DateFormat == "" {
+		column := ""
+		if len(data.IdFields) == 1 {
+			column = data.IdFields[0].Name
+		} else {
+			column = data.Fields[0].Name
+			notes = append(notes, "没有单列主键，需确认 shardingColumn")
+		}
+		if contiguousSuffixes(family.Suffixes) {
+			t.ActualDataNodes = fmt.Sprintf("ds_0.%s_${0..%s}", logic, last)
+		} else {
+			notes = append(notes, "后缀不是从 0 开始的连续整数，需按实际规则修改 algorithm-expression")
+		}
+		t.ShardingColumn, t.Algorithm, t.AlgorithmType = column, logic+"_inline", "INLINE"
+		t.Props = [][2]string{
+			{"algorithm-expression", yamlString(fmt.Sprintf("%s_${%s %% %d}", logic, column, len(family.Suffixes)))},
+		}
+		t.Note = strings.Join(notes, "；")
+		return t
+	}
+
+	t.Range += " (" + family.DateFormat + ")"
+	var layout, unit string
+	for _, format := range shardDateFormats {
+		if format.java == family.DateFormat {
+			layout, unit = format.layout, format.unit
+		}
+	}
+	column, ok := shardTimeColumn(data.Fields)
+	if !ok {
+		column = model.Field{Name: "create_time", JavaType: "LocalDateTime"}
+		notes = append(notes, "表中没有时间列，需修改 shardingColumn")
+	}
+
+	// 上界为最后一个分表所在区间的最后一刻，DATE 列按天比较
+	lower, _ := time.Parse(layout, first)
+	upper, _ := time.Parse(layout, last)
+	if unit == "MONTHS" {
+		upper = upper.AddDate(0, 1, 0)
+	} else {
+		upper = upper.AddDate(0, 0, 1)
+	}
+	pattern, goLayout := "yyyy-MM-dd HH:mm:ss", "2006-01-02 15:04:05"
+	upper = upper.Add(-time.Second)
+	if column.JavaType == "LocalDate" {
+		pattern, goLayout = "yyyy-MM-dd", "2006-01-02"
+	}
+
+	t.ShardingColumn, t.Algorithm, t.AlgorithmType = column.Name, logic+"_interval", "INTERVAL"
+	t.Props = [][2]string{
+		{"datetime-pattern", yamlString(pattern)},
+		{"datetime-lower", yamlString(lower.Format(goLayout))},
+		{"datetime-upper", yamlString(upper.Format(goLayout))},
+		{"sharding-suffix-pattern", yamlString(family.DateFormat)},
+		{"datetime-interval-amount", "1"},
+		{"datetime-interval-unit", yamlString(unit)},
+	}
+	t.Note = strings.Join(notes, "；")
+	return t
+}
+
+// shardTimeColumn 返回日期分表的分片列，优先取插入时自动填充的时间列 (如 create_time)
+func shardTimeColumn(fields []model.Field) (model.Field, bool) {
+	var candidate *model.Field
+	for i, f := range fields {
+		if _, ok := temporalValues[f.JavaType]; !ok || f.JavaType == "LocalTime" {
+			continue
+		}
+		if f.Fill == model.FillInsert {
+			return f, true
+		}
+		if candidate == nil {
+			candidate = &fields[i]
+		}
+	}
+	if candidate == nil {
+		return model.Field{}, false
+	}
+	return *candidate, true
+}
+
+// contiguousSuffixes 判断后缀是否为 0、1、2 ... 这样不补零的连续整数
+func contiguousSuffixes(suffixes []string) bool {
+	for i, s := range suffixes {
+		if s != strconv.Itoa(i) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"mybatis-plus-generator/internal/model"
+)
+
+func TestMergeShardTables(t *testing.T) {
+	columns := []model.Field{{Name: "id", Type: "bigint", IsId: true}, {Name: "amount", Type: "decimal(10,2)"}}
+	tables := []model.TableInfo{
+		{TableName: "order_10", Fields: columns},
+		{TableName: "order_2", Fields: columns},
+		{TableName: "log_202402", Fields: columns},
+		{TableName: "log_202401", Fields: columns},
+		// 列不同的同名分表、只有一张的分表和已存在逻辑表名的分表不合并
+		{TableName: "order_3", Fields: columns[:1]},
+		{TableName: "user_1", Fields: columns},
+		{TableName: "item_0", Fields: columns},
+		{TableName: "item_1", Fields: columns},
+		{TableName: "item", Fields: columns},
+		{TableName: "order_item", Fields: columns, ForeignKeys: []model.ForeignKey{{Columns: []string{"order_id"}, RefTable: "ORDER_2"}}},
+	}
+	merged := MergeShardTables(tables)
+
+	var names []string
+	for _, table := range merged {
+		names = append(names, table.TableName)
+	}
+	want := []string{"order", "log", "order_3", "user_1", "item_0", "item_1", "item", "order_item"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("tables = %v, want %v", names, want)
+	}
+	if got := *merged[0].Shard; !reflect.DeepEqual(got, model.ShardFamily{LogicTable: "order", Suffixes: []string{"2", "10"}}) {
+		t.Errorf("order Shard = %+v", got)
+	}
+	if got := *merged[1].Shard; !reflect.DeepEqual(got, model.ShardFamily{LogicTable: "log", Suffixes: []string{"202401", "202402"}, DateFormat: "yyyyMM"}) {
+		t.Errorf("log Shard = %+v", got)
+	}
+	if ref := merged[7].ForeignKeys[0].RefTable; ref != "order" {
+		t.Errorf("外键应改为引用逻辑表, got %s", ref)
+	}
+	if tables[9].ForeignKeys[0].RefTable != "ORDER_2" {
+		t.Error("不应修改传入的表")
+	}
+}
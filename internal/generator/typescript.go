@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"mybatis-plus-generator/internal/model"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// tsTypes 是 Java 类型对应的 TypeScript 类型，时间、UUID 等按 JSON 序列化后的字符串处理，
+// Long 由 TypeScriptConfig.LongAsString 决定
+var tsTypes = map[string]string{
+	"Integer":       "number",
+	"Short":         "number",
+	"Byte":          "number",
+	"Long":          "number",
+	"BigInteger":    "number",
+	"BigDecimal":    "number",
+	"Double":        "number",
+	"Float":         "number",
+	"Boolean":       "boolean",
+	"String":        "string",
+	"LocalDate":     "string",
+	"LocalDateTime": "string",
+	"LocalTime":     "string",
+	"Date":          "string",
+	"Duration":      "string",
+	"UUID":          "string",
+	"byte[]":        "string", // Jackson 将 byte[] 序列化为 Base64
+	"List":          "unknown[]",
+	// JSON 列
+	"Map<String, Object>": "Record<string, unknown>",
+	"JsonNode":            "unknown",
+}
+
+// typeScriptData 推导接口用到的前端类型，接口请求及其请求/响应类型与生成的 Controller 一致：
+// 配置了转换器时使用请求/响应对象，否则直接使用 DO
+func typeScriptData(data model.TemplateData, paths model.PathConfig) *model.TypeScriptData {
+	cfg := paths.TypeScript
+	module := strcase.ToKebab(data.EntityName)
+	page := openAPIPages[paths.ORM]
+	ts := &model.TypeScriptData{
+		FileName:    module + ".ts",
+		Module:      "./" + module,
+		Client:      cfg.Client,
+		Entity:      data.EntityName,
+		Create:      data.EntityName,
+		Update:      data.EntityName,
+		View:        data.EntityName,
+		PageRecords: page.records,
+		PageCounts:  page.counts,
+		RequestPath: requestPath(data, paths.Web),
+	}
+	if cfg.Client != "" {
+		ts.ClientFileName = module + ".api.ts"
+	}
+	var models []model.ModelClass
+	if data.Converter != nil {
+		models = data.Models
+	}
+	for _, class := range models {
+		switch class.Kind {
+		case model.ModelCreateRequest:
+			ts.Create = class.ClassName
+		case model.ModelUpdateRequest:
+			ts.Update = class.ClassName
+		case model.ModelQuery:
+			ts.Query = class.ClassName
+		case model.ModelVO:
+			ts.View = class.ClassName
+		}
+	}
+	ts.Page = ts.View + "Page"
+	imported := make(map[string]bool)
+	for _, name := range []string{ts.Create, ts.Update, ts.View, ts.Entity + "PageParams", ts.Page} {
+		if !imported[name] {
+			imported[name] = true
+			ts.Imports = append(ts.Imports, name)
+		}
+	}
+	if resultClass := paths.Web.ResultClass; resultClass != "" {
+		ts.Result = simpleClassName(resultClass)
+		ts.Imports = append(ts.Imports, ts.Result)
+	}
+
+	// 有默认值的列 (自增主键、DEFAULT) 和租户列新增时可以不传，与 OpenAPI 文档的 required 一致
+	if ts.Create == ts.Entity || ts.Update == ts.Entity || ts.View == ts.Entity {
+		entity := model.TSInterface{Name: ts.Entity, Comment: data.TableComment}
+		if entity.Comment == "" {
+			entity.Comment = data.TableName
+		}
+		for _, f := range data.Fields {
+			entity.Properties = append(entity.Properties, model.TSProperty{
+				Name:     f.PropertyName,
+				Type:     tsFieldType(data.Enums, f.JavaType, cfg.LongAsString),
+				Optional: !f.NotNull || f.HasDefault || f.Tenant,
+				Comment:  f.Comment,
+			})
+		}
+		ts.Interfaces = append(ts.Interfaces, entity)
+	}
+
+	// 请求/响应对象中只有带 @NotNull 的属性必填
+	for _, class := range models {
+		iface := model.TSInterface{Name: class.ClassName, Comment: class.Comment}
+		for _, f := range class.Fields {
+			iface.Properties = append(iface.Properties, model.TSProperty{
+				Name:     f.Name,
+				Type:     tsFieldType(data.Enums, f.JavaType, cfg.LongAsString),
+				Optional: !hasAnnotation(f.Annotations, "NotNull"),
+				Comment:  f.Comment,
+			})
+		}
+		ts.Interfaces = append(ts.Interfaces, iface)
+	}
+
+	if len(data.IdFields) == 1 {
+		ts.IdType = tsType(data.IdFields[0].JavaType, cfg.LongAsString)
+	}
+	return ts
+}
+
+// tsType 返回 Java 类型对应的 TypeScript 类型，List<T> 映射为 T[]，未知类型 (包括 JSON 列的内部类) 为 unknown
+func tsType(javaType string, longAsString bool) string {
+	if javaType == "Long" && longAsString {
+		return "string"
+	}
+	if strings.HasPrefix(javaType, "List<") && strings.HasSuffix(javaType, ">") {
+		return tsType(javaType[len("List<"):len(javaType)-1], longAsString) + "[]"
+	}
+	if t, ok := tsTypes[javaType]; ok {
+		return t
+	}
+	return "unknown"
+}
+
+// tsFieldType 在 tsType 的基础上将枚举映射为常量名的联合类型，与 Jackson 默认的序列化方式一致
+func tsFieldType(enums []model.EnumClass, javaType string, longAsString bool) string {
+	e, ok := findEnum(enums, javaType)
+	if !ok {
+		return tsType(javaType, longAsString)
+	}
+	names := make([]string, len(e.Constants))
+	for i, c := range e.Constants {
+		names[i] = "'" + c.Name + "'"
+	}
+	return strings.Join(names, " | ")
+}
+
+func hasAnnotation(annotations []string, name string) bool {
+	for _, annotation := range annotations {
+		if annotationName(annotation) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"embed"
+	"encoding/json"
 	"fmt"
 	"mybatis-plus-generator/internal/generator"
 	"mybatis-plus-generator/internal/model"
 	"mybatis-plus-generator/internal/parser"
 	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
 )
 
 //go:embed web/static/index.html
@@ -15,6 +19,12 @@ var staticFiles embed.FS
 //go:embed all:templates
 var templateFiles embed.FS // 我们将使用这个变量
 
+// ParseResult 是解析预览接口的返回结构，TemplateDatas 与 Tables 一一对应
+type ParseResult struct {
+	Tables        []model.TableInfo    `json:"tables"`
+	TemplateDatas []model.TemplateData `json:"templateDatas"`
+}
+
 // GenerateHandler 处理代码生成请求
 func GenerateHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -31,47 +41,398 @@ func GenerateHandler(w http.ResponseWriter, r *http.Request) {
 	// 1. 获取和验证输入
 	sql := r.FormValue("sql")
 	dbType := r.FormValue("dbType")
-	orm := model.ORM(r.FormValue("orm"))
-	if orm != model.ORMMyBatisPlus && orm != model.ORMMyBatisFlex {
-		orm = model.ORMMyBatisPlus
+	tableJSON := r.FormValue("table_info")
+	paths, err := readPathConfig(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	paths := model.PathConfig{
-		DOPath:      r.FormValue("do_path"),
-		MapperPath:  r.FormValue("mapper_path"),
-		DAOPath:     r.FormValue("dao_path"),
-		DAOImplPath: r.FormValue("dao_impl_path"),
-		XMLPath:     r.FormValue("xml_path"),
-		ORM:         orm,
+	if (sql == "" && tableJSON == "") || dbType == "" || paths.DOPath == "" || paths.MapperPath == "" || paths.DAOPath == "" || paths.DAOImplPath == "" || paths.XMLPath == "" {
+		http.Error(w, "All fields are required", http.StatusBadRequest)
+		return
 	}
 
-	if sql == "" || dbType == "" || paths.DOPath == "" || paths.MapperPath == "" || paths.DAOPath == "" || paths.DAOImplPath == "" || paths.XMLPath == "" {
-		http.Error(w, "All fields are required", http.StatusBadRequest)
+	// 2. 解析 SQL 中的所有建表语句，若提交了预览中编辑过的模型则直接使用
+	var tables []model.TableInfo
+	if tableJSON != "" {
+		tables, err = parseTableInfo(tableJSON, dbType)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// 只提交了一张表时不能丢弃 SQL 中的其他表及它们之间的关联，分表按合并后的逻辑表计数
+		if len(tables) == 1 && sql != "" {
+			if parsed, err := parseSQL(sql, dbType); err == nil {
+				if paths.ShardMerge {
+					parsed = generator.MergeShardTables(parsed)
+				}
+				if len(parsed) > 1 {
+					http.Error(w, fmt.Sprintf("table_info 只包含一张表，但 SQL 中有 %d 张表，请提交所有表的模型", len(parsed)), http.StatusBadRequest)
+					return
+				}
+			}
+		}
+	} else {
+		tables, err = parseSQL(sql, dbType)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	// 预览返回的模型已经合并过，再次合并不会改变结果
+	if paths.ShardMerge {
+		tables = generator.MergeShardTables(tables)
+	}
+
+	// 3. 准备模板数据，同批生成的表之间按外键建立关联；任一表不满足生成条件时不写入任何文件
+	templateDatas := prepareTemplateDatas(tables, paths)
+	for _, templateData := range templateDatas {
+		if err := generator.ValidateTemplateData(templateData, paths); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 4. 生成文件
+	for _, templateData := range templateDatas {
+		if err := generator.GenerateFiles(templateData, paths, templateFiles); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to generate code: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// 自动填充处理器在项目中只能有一个，汇总本批所有表生成
+	if err := generator.GenerateFillHandler(templateDatas, paths, templateFiles); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to generate code: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	// 2. 解析 SQL
-	p, err := parser.NewParser(dbType)
+	// 插件配置和分表路由汇总本批所有表，只生成一次
+	if err := generator.GeneratePluginConfig(templateDatas, tables, paths, templateFiles); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to generate code: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := generator.GenerateShardRules(templateDatas, tables, paths, templateFiles); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to generate code: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// 5. 返回成功响应，并列出被改名的属性
+	var sb strings.Builder
+	sb.WriteString("Code generated successfully!")
+	for _, templateData := range templateDatas {
+		for _, rename := range templateData.Renames {
+			column := rename.Column
+			if len(templateDatas) > 1 {
+				column = templateData.TableName + "." + column
+			}
+			fmt.Fprintf(&sb, "\n列 %s 映射为属性 %s (%s)", column, rename.To, rename.Reason)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(sb.String()))
+}
+
+// ParseHandler 只解析 SQL，返回推断出的表模型与模板数据，不写入任何文件
+func ParseHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sql := r.FormValue("sql")
+	dbType := r.FormValue("dbType")
+	if sql == "" || dbType == "" {
+		http.Error(w, "sql and dbType are required", http.StatusBadRequest)
+		return
+	}
+
+	paths, err := readPathConfig(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	tableInfo, err := p.Parse(sql)
+
+	tables, err := parseSQL(sql, dbType)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to parse SQL: %v", err), http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if paths.ShardMerge {
+		tables = generator.MergeShardTables(tables)
+	}
 
-	// 3. 准备模板数据
-	templateData := generator.PrepareTemplateData(tableInfo, paths)
+	result := ParseResult{
+		Tables:        tables,
+		TemplateDatas: prepareTemplateDatas(tables, paths),
+	}
 
-	// 4. 生成文件
-	if err := generator.GenerateFiles(templateData, paths, templateFiles); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to generate code: %v", err), http.StatusInternalServerError)
-		return
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to encode result: %v", err), http.StatusInternalServerError)
 	}
+}
 
-	// 5. 返回成功响应
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Code generated successfully!"))
+func readPathConfig(r *http.Request) (model.PathConfig, error) {
+	orm := model.ORM(r.FormValue("orm"))
+	if !model.SupportedORM(orm) {
+		orm = model.ORMMyBatisPlus
+	}
+	lang := model.Language(r.FormValue("language"))
+	if lang == "" {
+		lang = model.LanguageJava
+	}
+	if !model.SupportedLanguage(lang, orm) {
+		return model.PathConfig{}, fmt.Errorf("%s 不支持生成 %s 代码，Kotlin 目前仅支持 mybatis-plus 和 mybatis-flex", orm, lang)
+	}
+
+	doStyle := model.DOStyle(r.FormValue("do_style"))
+	if doStyle == "" {
+		doStyle = model.DOStyleLombok
+	}
+	if !model.SupportedDOStyle(doStyle, orm) {
+		return model.PathConfig{}, fmt.Errorf("%s 不支持 %s 风格的 DO", orm, doStyle)
+	}
+	xml := model.XMLConfig{
+		ResultMap: r.FormValue("xml_result_map") != "",
+		CRUD:      r.FormValue("xml_crud") != "",
+	}
+	if doStyle == model.DOStyleRecord && (xml.ResultMap || xml.CRUD) {
+		return model.PathConfig{}, fmt.Errorf("record 没有 setter，不能与 XML resultMap/CRUD 一起使用")
+	}
+
+	baseDO := model.BaseDOConfig{
+		ClassName: strings.TrimSpace(r.FormValue("base_class_name")),
+		Package:   strings.TrimSpace(r.FormValue("base_package")),
+		Columns:   splitList(r.FormValue("base_columns")),
+	}
+	if baseDO.ClassName != "" && (lang != model.LanguageJava || doStyle == model.DOStyleRecord || (orm != model.ORMMyBatisPlus && orm != model.ORMMyBatisFlex)) {
+		return model.PathConfig{}, fmt.Errorf("父类 %s 仅支持 Java 的 mybatis-plus 和 mybatis-flex，且 DO 不能是 record", baseDO.ClassName)
+	}
+
+	// Service/Controller 可选，ServiceImpl 默认放在 Service 目录的 impl 子包下
+	servicePath := strings.TrimSpace(r.FormValue("service_path"))
+	serviceImplPath := strings.TrimSpace(r.FormValue("service_impl_path"))
+	controllerPath := strings.TrimSpace(r.FormValue("controller_path"))
+	if servicePath == "" && controllerPath != "" {
+		return model.PathConfig{}, fmt.Errorf("生成 Controller 需要同时配置 Service 路径")
+	}
+	if servicePath != "" && lang != model.LanguageJava {
+		return model.PathConfig{}, fmt.Errorf("Service/Controller 目前仅支持 Java")
+	}
+	modelPaths := [4]string{}
+	for i, name := range []string{"create_request_path", "update_request_path", "query_path", "vo_path"} {
+		modelPaths[i] = strings.TrimSpace(r.FormValue(name))
+		if modelPaths[i] != "" && lang != model.LanguageJava {
+			return model.PathConfig{}, fmt.Errorf("请求/响应对象目前仅支持 Java")
+		}
+	}
+	converterPath := strings.TrimSpace(r.FormValue("converter_path"))
+	if converterPath != "" {
+		switch {
+		case lang != model.LanguageJava:
+			return model.PathConfig{}, fmt.Errorf("MapStruct 转换器目前仅支持 Java")
+		case doStyle == model.DOStyleRecord:
+			return model.PathConfig{}, fmt.Errorf("record 没有 setter，不能生成 MapStruct 转换器")
+		case modelPaths == [4]string{}:
+			return model.PathConfig{}, fmt.Errorf("MapStruct 转换器需要配置 CreateRequest、UpdateRequest、Query 或 VO 的路径")
+		}
+	}
+	// Service/Controller 通过转换器使用请求/响应对象，避免直接绑定 DO
+	if servicePath != "" && modelPaths != [4]string{} && converterPath == "" {
+		return model.PathConfig{}, fmt.Errorf("Service 使用请求/响应对象时需要配置 MapStruct 转换器路径")
+	}
+	apiDoc := model.APIDocStyle(strings.TrimSpace(r.FormValue("api_doc")))
+	if !model.SupportedAPIDoc(apiDoc) {
+		return model.PathConfig{}, fmt.Errorf("不支持的接口文档注解: %s", apiDoc)
+	}
+	if apiDoc != "" && lang != model.LanguageJava {
+		return model.PathConfig{}, fmt.Errorf("接口文档注解目前仅支持 Java")
+	}
+	tsClient := model.TSClient(strings.TrimSpace(r.FormValue("ts_client")))
+	if !model.SupportedTSClient(tsClient) {
+		return model.PathConfig{}, fmt.Errorf("不支持的前端请求库: %s", tsClient)
+	}
+	jsonType := model.JSONType(strings.TrimSpace(r.FormValue("json_type")))
+	if !model.SupportedJSONType(jsonType) {
+		return model.PathConfig{}, fmt.Errorf("不支持的 JSON 列类型: %s", jsonType)
+	}
+	if jsonType != "" && (lang != model.LanguageJava || (orm != model.ORMMyBatisPlus && orm != model.ORMMyBatisFlex)) {
+		return model.PathConfig{}, fmt.Errorf("JSON 列映射目前仅支持 Java 的 mybatis-plus 和 mybatis-flex")
+	}
+	shardMerge := r.FormValue("shard_merge") != ""
+	shardRule := model.ShardRule(strings.TrimSpace(r.FormValue("shard_rule")))
+	shardingPath := strings.TrimSpace(r.FormValue("sharding_path"))
+	switch {
+	case !model.SupportedShardRule(shardRule):
+		return model.PathConfig{}, fmt.Errorf("不支持的分表路由配置: %s", shardRule)
+	case shardRule != "" && !shardMerge:
+		return model.PathConfig{}, fmt.Errorf("生成分表路由配置需要开启分表合并")
+	case shardRule == model.ShardRuleShardingSphere && shardingPath == "":
+		return model.PathConfig{}, fmt.Errorf("生成 ShardingSphere 分片规则需要配置 sharding_path")
+	case shardRule == model.ShardRuleDynamicTable && (lang != model.LanguageJava || orm != model.ORMMyBatisPlus):
+		return model.PathConfig{}, fmt.Errorf("动态表名处理器目前仅支持 Java 的 mybatis-plus")
+	}
+
+	// 配置了租户列或动态表名时需要注册对应的插件，MybatisPlusConfig 随之生成
+	tenantColumn := strings.TrimSpace(r.FormValue("tenant_column"))
+	pluginConfig := r.FormValue("plugin_config") != "" || (tenantColumn != "" && orm == model.ORMMyBatisPlus) || shardRule == model.ShardRuleDynamicTable
+	if pluginConfig && orm != model.ORMMyBatisPlus {
+		return model.PathConfig{}, fmt.Errorf("MybatisPlusConfig 仅支持 mybatis-plus")
+	}
+	enumPath := strings.TrimSpace(r.FormValue("enum_path"))
+	enumRegex := strings.TrimSpace(r.FormValue("enum_regex"))
+	if enumPath != "" {
+		if lang != model.LanguageJava || (orm != model.ORMMyBatisPlus && orm != model.ORMMyBatisFlex) {
+			return model.PathConfig{}, fmt.Errorf("枚举目前仅支持 Java 的 mybatis-plus 和 mybatis-flex")
+		}
+		if enumRegex != "" {
+			re, err := regexp.Compile(enumRegex)
+			if err != nil {
+				return model.PathConfig{}, fmt.Errorf("invalid enum_regex: %v", err)
+			}
+			if re.SubexpIndex("code") < 0 || re.SubexpIndex("desc") < 0 {
+				return model.PathConfig{}, fmt.Errorf("enum_regex 需要包含 (?P<code>...) 和 (?P<desc>...) 两个命名分组")
+			}
+		}
+	}
+	if servicePath != "" && serviceImplPath == "" {
+		serviceImplPath = filepath.Join(servicePath, "impl")
+	}
+
+	naming := model.NamingConfig{
+		TablePrefixes:      splitList(r.FormValue("table_prefixes")),
+		TableSuffixes:      splitList(r.FormValue("table_suffixes")),
+		TableRegex:         strings.TrimSpace(r.FormValue("table_regex")),
+		DOPattern:          r.FormValue("do_pattern"),
+		MapperPattern:      r.FormValue("mapper_pattern"),
+		DAOPattern:         r.FormValue("dao_pattern"),
+		DAOImplPattern:     r.FormValue("dao_impl_pattern"),
+		ServicePattern:     r.FormValue("service_pattern"),
+		ServiceImplPattern: r.FormValue("service_impl_pattern"),
+		ControllerPattern:  r.FormValue("controller_pattern"),
+		ConverterPattern:   r.FormValue("converter_pattern"),
+		ColumnPrefixes:     splitList(r.FormValue("column_prefixes")),
+		PropertyPrefix:     strings.TrimSpace(r.FormValue("property_prefix")),
+		PropertySuffix:     strings.TrimSpace(r.FormValue("property_suffix")),
+	}
+	if naming.TableRegex != "" {
+		if _, err := regexp.Compile(naming.TableRegex); err != nil {
+			return model.PathConfig{}, fmt.Errorf("invalid table_regex: %v", err)
+		}
+	}
+
+	return model.PathConfig{
+		DOPath:            r.FormValue("do_path"),
+		MapperPath:        r.FormValue("mapper_path"),
+		DAOPath:           r.FormValue("dao_path"),
+		DAOImplPath:       r.FormValue("dao_impl_path"),
+		XMLPath:           r.FormValue("xml_path"),
+		ServicePath:       servicePath,
+		ServiceImplPath:   serviceImplPath,
+		ControllerPath:    controllerPath,
+		CreateRequestPath: modelPaths[0],
+		UpdateRequestPath: modelPaths[1],
+		QueryPath:         modelPaths[2],
+		VOPath:            modelPaths[3],
+		ConverterPath:     converterPath,
+		APIDoc:            apiDoc,
+		OpenAPIPath:       strings.TrimSpace(r.FormValue("openapi_path")),
+		TypeScriptPath:    strings.TrimSpace(r.FormValue("ts_path")),
+		EnumPath:          enumPath,
+		EnumRegex:         enumRegex,
+		JSONType:          jsonType,
+		PluginConfig:      pluginConfig,
+		ShardMerge:        shardMerge,
+		ShardRule:         shardRule,
+		ShardingPath:      shardingPath,
+		ORM:               orm,
+		Language:          lang,
+		Naming:            naming,
+		XML:               xml,
+		BaseDO:            baseDO,
+		Web: model.WebConfig{
+			ResultClass:  strings.TrimSpace(r.FormValue("result_class")),
+			ResultMethod: strings.TrimSpace(r.FormValue("result_method")),
+			URLPrefix:    strings.TrimSpace(r.FormValue("url_prefix")),
+		},
+		TypeScript: model.TypeScriptConfig{
+			Client:       tsClient,
+			LongAsString: r.FormValue("ts_long_as_string") != "",
+		},
+		ColumnRules: model.ColumnRules{
+			LogicDelete: splitList(r.FormValue("logic_delete_columns")),
+			Version:     splitList(r.FormValue("version_columns")),
+			InsertFill:  splitList(r.FormValue("insert_fill_columns")),
+			UpdateFill:  splitList(r.FormValue("update_fill_columns")),
+			Tenant:      tenantColumn,
+		},
+		DO: model.DOConfig{
+			Style:             doStyle,
+			Data:              r.FormValue("lombok_data") != "",
+			GetterSetter:      r.FormValue("lombok_getter_setter") != "",
+			Builder:           r.FormValue("lombok_builder") != "",
+			Chain:             r.FormValue("lombok_chain") != "",
+			EqualsAndHashCode: r.FormValue("lombok_equals") != "",
+			CallSuper:         r.FormValue("lombok_call_super") != "",
+		},
+	}, nil
+}
+
+// splitList 将逗号分隔的表单值拆分为去除空白后的列表
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTableInfo 读取预览中编辑过的模型，预览提交所有表的数组，也兼容单个表对象
+func parseTableInfo(tableJSON, dbType string) ([]model.TableInfo, error) {
+	var tables []model.TableInfo
+	if strings.HasPrefix(strings.TrimSpace(tableJSON), "[") {
+		if err := json.Unmarshal([]byte(tableJSON), &tables); err != nil {
+			return nil, fmt.Errorf("Invalid table_info: %v", err)
+		}
+	} else {
+		var tableInfo model.TableInfo
+		if err := json.Unmarshal([]byte(tableJSON), &tableInfo); err != nil {
+			return nil, fmt.Errorf("Invalid table_info: %v", err)
+		}
+		tables = []model.TableInfo{tableInfo}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("table_info 中没有表")
+	}
+	for i := range tables {
+		if tables[i].DbType == "" {
+			tables[i].DbType = dbType
+		}
+	}
+	return tables, nil
+}
+
+func parseSQL(sql, dbType string) ([]model.TableInfo, error) {
+	p, err := parser.NewParser(dbType)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := p.ParseAll(sql)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse SQL: %v", err)
+	}
+	return tables, nil
+}
+
+func prepareTemplateDatas(tables []model.TableInfo, paths model.PathConfig) []model.TemplateData {
+	templateDatas := make([]model.TemplateData, len(tables))
+	for i, tableInfo := range tables {
+		templateDatas[i] = generator.PrepareTemplateData(tableInfo, paths)
+	}
+	generator.LinkAssociations(templateDatas, tables, paths)
+	return templateDatas
 }
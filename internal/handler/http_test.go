@@ -0,0 +1,619 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generate 以表单提交 SQL 并把代码生成到临时目录，返回 src/main 目录；额外参数按 key, value 成对传入
+func generate(t *testing.T, sql string, extra ...string) string {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), "src", "main")
+	java := filepath.Join(root, "java", "com", "demo")
+	form := url.Values{
+		"sql":           {sql},
+		"dbType":        {"mysql"},
+		"do_path":       {filepath.Join(java, "entity")},
+		"mapper_path":   {filepath.Join(java, "dao", "mapper")},
+		"dao_path":      {filepath.Join(java, "dao")},
+		"dao_impl_path": {filepath.Join(java, "dao", "impl")},
+		"xml_path":      {filepath.Join(root, "resources", "mapper")},
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		form.Set(extra[i], extra[i+1])
+	}
+	if rec := postGenerate(form); rec.Code != http.StatusOK {
+		t.Fatalf("GenerateHandler = %d: %s", rec.Code, rec.Body.String())
+	}
+	return root
+}
+
+// postGenerate 以表单调用 GenerateHandler
+func postGenerate(form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	GenerateHandler(rec, req)
+	return rec
+}
+
+// readGenerated 读取 src/main 下生成的文件
+func readGenerated(t *testing.T, root, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		t.Fatalf("读取生成的文件: %v", err)
+	}
+	return string(content)
+}
+
+func assertContains(t *testing.T, content string, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !strings.Contains(content, want) {
+			t.Errorf("生成的代码缺少 %q:\n%s", want, content)
+		}
+	}
+}
+
+func assertNotContains(t *testing.T, content string, unwanted ...string) {
+	t.Helper()
+	for _, s := range unwanted {
+		if strings.Contains(content, s) {
+			t.Errorf("生成的代码不应包含 %q:\n%s", s, content)
+		}
+	}
+}
+
+func TestGenerateColumnMappings(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint PRIMARY KEY, `order` int, userName varchar(20), user_code varchar(20))"
+	for _, orm := range []string{"mybatis-plus", "mybatis-flex"} {
+		t.Run(orm, func(t *testing.T) {
+			root := generate(t, sql, "orm", orm)
+			do := readGenerated(t, root, "java/com/demo/entity/TOrderDO.java")
+			annotation := "@TableField"
+			if orm == "mybatis-flex" {
+				annotation = "@Column"
+			}
+			assertContains(t, do,
+				annotation+"(\"`order`\")",
+				annotation+"(\"userName\")",
+				"private String userCode;",
+			)
+			if strings.Contains(do, annotation+"(\"user_code\")") {
+				t.Errorf("可按驼峰自动映射的列不应生成 %s:\n%s", annotation, do)
+			}
+		})
+	}
+}
+
+func TestGenerateQuotedTableName(t *testing.T) {
+	sql := `CREATE TABLE "Orders" (id bigint PRIMARY KEY, "2fa_code" varchar(8))`
+	root := generate(t, sql, "dbType", "postgresql", "orm", "mybatis-plus")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/OrdersDO.java"),
+		`@TableName("\"Orders\"")`,
+		`@TableField("\"2fa_code\"")`,
+	)
+	root = generate(t, sql, "dbType", "postgresql", "orm", "mybatis-flex")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/OrdersDO.java"), `@Table("\"Orders\"")`)
+	root = generate(t, sql, "dbType", "postgresql", "orm", "mybatis-plus", "language", "kotlin")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/OrdersDO.kt"), `@TableName("\"Orders\"")`)
+}
+
+func TestGenerateGeneratedValue(t *testing.T) {
+	for _, orm := range []string{"jpa", "tk-mybatis"} {
+		t.Run(orm, func(t *testing.T) {
+			root := generate(t, "CREATE TABLE t_order (id bigint AUTO_INCREMENT PRIMARY KEY, name varchar(64))", "orm", orm)
+			assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"),
+				"@Id\n    @GeneratedValue(strategy = GenerationType.IDENTITY)\n",
+			)
+
+			// 主键由应用生成时不能声明 IDENTITY
+			for _, sql := range []string{
+				"CREATE TABLE t_order (id bigint PRIMARY KEY, name varchar(64))",
+				"CREATE TABLE t_order (id varchar(32) PRIMARY KEY, name varchar(64))",
+			} {
+				root := generate(t, sql, "orm", orm)
+				assertNotContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"), "GeneratedValue")
+			}
+		})
+	}
+}
+
+func TestGenerateKotlin(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint PRIMARY KEY, name varchar(20) NOT NULL, amount decimal(10,2))"
+	for _, orm := range []string{"mybatis-plus", "mybatis-flex"} {
+		t.Run(orm, func(t *testing.T) {
+			root := generate(t, sql, "orm", orm, "language", "kotlin")
+			// 所有属性都有默认值，MyBatis 才能使用无参构造器
+			assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.kt"),
+				"data class TOrderDO(",
+				"var id: Long? = null,",
+				"var name: String? = null,",
+				"var amount: BigDecimal? = null\n)",
+			)
+			assertContains(t, readGenerated(t, root, "resources/mapper/TOrderMapper.xml"),
+				`<mapper namespace="com.demo.dao.mapper.TOrderMapper">`,
+			)
+		})
+	}
+}
+
+func TestGenerateColumnRules(t *testing.T) {
+	sql := `CREATE TABLE t_order (id bigint PRIMARY KEY, deleted tinyint(1), version int,
+		create_time datetime, update_time datetime, create_by varchar(32))`
+	root := generate(t, sql,
+		"logic_delete_columns", "deleted",
+		"version_columns", "version",
+		"insert_fill_columns", "create_time,create_by",
+		"update_fill_columns", "update_time",
+	)
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"),
+		"@TableLogic\n    private Boolean deleted;",
+		"@Version\n    private Integer version;",
+		"@TableField(fill = FieldFill.INSERT)\n    private LocalDateTime createTime;",
+		"@TableField(fill = FieldFill.INSERT_UPDATE)\n    private LocalDateTime updateTime;",
+	)
+	assertContains(t, readGenerated(t, root, "java/com/demo/dao/handler/MyMetaObjectHandler.java"),
+		"package com.demo.dao.handler;",
+		`this.strictInsertFill(metaObject, "createTime", LocalDateTime.class, LocalDateTime.now());`,
+		`this.strictInsertFill(metaObject, "createBy", String.class, currentOperator());`,
+		`this.strictUpdateFill(metaObject, "updateTime", LocalDateTime.class, LocalDateTime.now());`,
+	)
+}
+
+func TestGenerateCompositeKeyDAO(t *testing.T) {
+	sql := "CREATE TABLE t_item (order_id bigint NOT NULL, line_no int NOT NULL, sku varchar(20), PRIMARY KEY (order_id, line_no))"
+	root := generate(t, sql)
+	do := readGenerated(t, root, "java/com/demo/entity/TItemDO.java")
+	if strings.Contains(do, "@TableId") {
+		t.Errorf("联合主键的 DO 不应有 @TableId:\n%s", do)
+	}
+	dao := readGenerated(t, root, "java/com/demo/dao/TItemDAO.java")
+	if strings.Contains(dao, "extends IService") {
+		t.Errorf("联合主键的 DAO 不应继承 IService:\n%s", dao)
+	}
+	assertContains(t, dao,
+		"TItemDO getByIds(Long orderId, Integer lineNo);",
+		"boolean updateByIds(TItemDO entity);",
+		"boolean deleteByIds(Long orderId, Integer lineNo);",
+	)
+	assertContains(t, readGenerated(t, root, "java/com/demo/dao/impl/TItemDAOImpl.java"),
+		".eq(TItemDO::getOrderId, orderId)",
+		".eq(TItemDO::getLineNo, lineNo)",
+	)
+}
+
+func TestGenerateAssociations(t *testing.T) {
+	sql := `CREATE TABLE t_order (id bigint PRIMARY KEY);
+CREATE TABLE t_order_item (id bigint PRIMARY KEY, order_id bigint, CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES t_order (id));`
+
+	root := generate(t, sql, "orm", "mybatis-plus")
+	assertContains(t, readGenerated(t, root, "resources/mapper/TOrderMapper.xml"),
+		`<collection property="items" ofType="com.demo.entity.TOrderItemDO" columnPrefix="items__">`,
+		"left join t_order_item r on r.order_id = t.id",
+	)
+	assertContains(t, readGenerated(t, root, "java/com/demo/vo/TOrderItemWithOrderVO.java"),
+		"package com.demo.vo;",
+		"private TOrderDO order;",
+	)
+
+	root = generate(t, sql, "orm", "mybatis-flex")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"),
+		`@RelationOneToMany(selfField = "id", targetField = "orderId")`,
+		"private List<TOrderItemDO> items;",
+	)
+}
+
+func TestGenerateTableInfoGuard(t *testing.T) {
+	tests := []struct {
+		name, sql, table, shardMerge string
+		want                         int
+	}{
+		{"丢弃其他表", "CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (id int PRIMARY KEY);", "a", "", http.StatusBadRequest},
+		{"单表", "CREATE TABLE a (id int PRIMARY KEY);", "a", "", http.StatusOK},
+		{"合并后的分表", "CREATE TABLE order_0 (id int PRIMARY KEY); CREATE TABLE order_1 (id int PRIMARY KEY); CREATE TABLE order_2 (id int PRIMARY KEY);", "order", "1", http.StatusOK},
+		{"未合并的分表", "CREATE TABLE order_0 (id int PRIMARY KEY); CREATE TABLE order_1 (id int PRIMARY KEY);", "order_0", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{
+				"sql":           {tt.sql},
+				"table_info":    {`{"tableName": "` + tt.table + `", "fields": [{"name": "id", "type": "int", "isId": true}]}`},
+				"shard_merge":   {tt.shardMerge},
+				"dbType":        {"mysql"},
+				"do_path":       {t.TempDir()},
+				"mapper_path":   {t.TempDir()},
+				"dao_path":      {t.TempDir()},
+				"dao_impl_path": {t.TempDir()},
+				"xml_path":      {t.TempDir()},
+			}
+			if rec := postGenerate(form); rec.Code != tt.want {
+				t.Errorf("GenerateHandler = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGenerateValidatesAllTables(t *testing.T) {
+	doPath := t.TempDir()
+	form := url.Values{
+		"sql":           {"CREATE TABLE a (id bigint PRIMARY KEY); CREATE TABLE b (name varchar(10));"},
+		"dbType":        {"mysql"},
+		"orm":           {"jpa"},
+		"do_path":       {doPath},
+		"mapper_path":   {t.TempDir()},
+		"dao_path":      {t.TempDir()},
+		"dao_impl_path": {t.TempDir()},
+		"xml_path":      {t.TempDir()},
+	}
+	if rec := postGenerate(form); rec.Code != http.StatusBadRequest {
+		t.Fatalf("GenerateHandler = %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+	if entries, _ := os.ReadDir(doPath); len(entries) != 0 {
+		t.Errorf("校验失败时不应写入文件，实际写入 %d 个", len(entries))
+	}
+}
+
+func TestGenerateService(t *testing.T) {
+	sql := "CREATE TABLE t_order_item (id bigint PRIMARY KEY, name varchar(64))"
+	// Service 与 Controller 生成到另一个源码目录
+	web := filepath.Join(t.TempDir(), "src", "main")
+	java := filepath.Join(web, "java", "com", "demo")
+	generate(t, sql,
+		"orm", "mybatis-plus",
+		"service_path", filepath.Join(java, "service"),
+		"controller_path", filepath.Join(java, "controller"),
+		"result_class", "com.demo.common.Result",
+		"url_prefix", "/api",
+	)
+	assertContains(t, readGenerated(t, web, "java/com/demo/service/TOrderItemService.java"),
+		"package com.demo.service;",
+		"TOrderItemDO create(TOrderItemDO record);",
+	)
+	assertContains(t, readGenerated(t, web, "java/com/demo/service/impl/TOrderItemServiceImpl.java"),
+		"package com.demo.service.impl;",
+		"public class TOrderItemServiceImpl implements TOrderItemService {",
+		"private final TOrderItemDAO tOrderItemDAO;",
+	)
+	assertContains(t, readGenerated(t, web, "java/com/demo/controller/TOrderItemController.java"),
+		"import com.demo.common.Result;",
+		`@RequestMapping("/api/t-order-item")`,
+		"private final TOrderItemService tOrderItemService;",
+		"return Result.success(tOrderItemService.getById(id));",
+	)
+}
+
+func TestGenerateServiceModels(t *testing.T) {
+	sql := `CREATE TABLE t_order (id bigint AUTO_INCREMENT PRIMARY KEY, name varchar(64) NOT NULL,
+		tenant_id bigint, deleted tinyint(1), create_time datetime)`
+	web := filepath.Join(t.TempDir(), "src", "main")
+	java := filepath.Join(web, "java", "com", "demo")
+	generate(t, sql,
+		"orm", "mybatis-plus",
+		"service_path", filepath.Join(java, "service"),
+		"controller_path", filepath.Join(java, "controller"),
+		"create_request_path", filepath.Join(java, "model", "request"),
+		"update_request_path", filepath.Join(java, "model", "request"),
+		"query_path", filepath.Join(java, "model", "query"),
+		"vo_path", filepath.Join(java, "model", "vo"),
+		"converter_path", filepath.Join(java, "convert"),
+		"logic_delete_columns", "deleted",
+		"tenant_column", "tenant_id",
+	)
+
+	// Controller 不直接绑定 DO，客户端无法写入主键、租户和逻辑删除字段
+	controller := readGenerated(t, web, "java/com/demo/controller/TOrderController.java")
+	assertContains(t, controller,
+		"public IPage<TOrderVO> page(@Valid TOrderQuery query) {",
+		"public TOrderVO create(@RequestBody @Valid TOrderCreateRequest request) {",
+		"public boolean update(@RequestBody @Valid TOrderUpdateRequest request) {",
+	)
+	assertNotContains(t, controller, "TOrderDO")
+	assertContains(t, readGenerated(t, web, "java/com/demo/service/impl/TOrderServiceImpl.java"),
+		"Wrappers.lambdaQuery(tOrderConverter.toCondition(query))",
+		".ge(query.getCreateTimeStart() != null, TOrderDO::getCreateTime, query.getCreateTimeStart())",
+		".convert(tOrderConverter::toVO);",
+		"TOrderDO record = tOrderConverter.toDO(request);",
+		"TOrderDO record = tOrderDAO.getById(request.getId());",
+		"tOrderConverter.updateDO(request, record);",
+	)
+	assertContains(t, readGenerated(t, web, "java/com/demo/convert/TOrderConverter.java"),
+		"@Mapping(target = \"createTime\", ignore = true)\n    TOrderDO toCondition(TOrderQuery query);",
+	)
+
+	// 使用请求/响应对象时必须配置转换器
+	form := url.Values{
+		"sql":           {sql},
+		"dbType":        {"mysql"},
+		"do_path":       {t.TempDir()},
+		"mapper_path":   {t.TempDir()},
+		"dao_path":      {t.TempDir()},
+		"dao_impl_path": {t.TempDir()},
+		"xml_path":      {t.TempDir()},
+		"service_path":  {t.TempDir()},
+		"vo_path":       {t.TempDir()},
+	}
+	if rec := postGenerate(form); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "转换器") {
+		t.Errorf("GenerateHandler = %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestGenerateModels(t *testing.T) {
+	sql := `CREATE TABLE t_order (id bigint AUTO_INCREMENT PRIMARY KEY, name varchar(64) NOT NULL,
+		amount decimal(10,2), deleted tinyint(1), version int, create_time datetime)`
+	web := filepath.Join(t.TempDir(), "src", "main")
+	java := filepath.Join(web, "java", "com", "demo", "model")
+	generate(t, sql,
+		"create_request_path", filepath.Join(java, "request"),
+		"update_request_path", filepath.Join(java, "request"),
+		"query_path", filepath.Join(java, "query"),
+		"vo_path", filepath.Join(java, "vo"),
+		"logic_delete_columns", "deleted",
+		"version_columns", "version",
+		"insert_fill_columns", "create_time",
+	)
+
+	create := readGenerated(t, web, "java/com/demo/model/request/TOrderCreateRequest.java")
+	assertContains(t, create,
+		"package com.demo.model.request;",
+		"@NotNull\n    @Size(max = 64)\n    private String name;",
+		"@Digits(integer = 8, fraction = 2)\n    private BigDecimal amount;",
+	)
+	// 自增主键、自动填充、逻辑删除和乐观锁字段由服务端维护
+	assertNotContains(t, create, "Long id;", "deleted;", "version;", "createTime;")
+
+	update := readGenerated(t, web, "java/com/demo/model/request/TOrderUpdateRequest.java")
+	assertContains(t, update, "@NotNull\n    private Long id;", "private Integer version;")
+	assertNotContains(t, update, "deleted;", "createTime;")
+
+	assertContains(t, readGenerated(t, web, "java/com/demo/model/query/TOrderQuery.java"),
+		"private Integer pageNum = 1;",
+		"private LocalDateTime createTimeStart;",
+		"private LocalDateTime createTimeEnd;",
+	)
+	assertNotContains(t, readGenerated(t, web, "java/com/demo/model/vo/TOrderVO.java"), "deleted;")
+}
+
+func TestGenerateConverter(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint AUTO_INCREMENT PRIMARY KEY, name varchar(64), deleted tinyint(1))"
+	web := filepath.Join(t.TempDir(), "src", "main")
+	java := filepath.Join(web, "java", "com", "demo")
+	generate(t, sql,
+		"create_request_path", filepath.Join(java, "model"),
+		"update_request_path", filepath.Join(java, "model"),
+		"vo_path", filepath.Join(java, "model"),
+		"converter_path", filepath.Join(java, "convert"),
+		"logic_delete_columns", "deleted",
+	)
+	assertContains(t, readGenerated(t, web, "java/com/demo/convert/TOrderConverter.java"),
+		"import com.demo.model.TOrderCreateRequest;",
+		"TOrderVO toVO(TOrderDO entity);",
+		"@Mapping(target = \"id\", ignore = true)\n    @Mapping(target = \"deleted\", ignore = true)\n    TOrderDO toDO(TOrderCreateRequest request);",
+		"@Mapping(target = \"id\", ignore = true)\n    @Mapping(target = \"deleted\", ignore = true)\n    void updateDO(TOrderUpdateRequest request, @MappingTarget TOrderDO entity);",
+	)
+}
+
+func TestGenerateAPIDoc(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint PRIMARY KEY COMMENT '主键', name varchar(64) NOT NULL COMMENT '名称') COMMENT='订单'"
+	root := generate(t, sql, "api_doc", "springdoc")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"),
+		"import io.swagger.v3.oas.annotations.media.Schema;",
+		`@Schema(description = "订单")`,
+		`@Schema(description = "主键", example = "1", requiredMode = Schema.RequiredMode.REQUIRED)`,
+	)
+
+	root = generate(t, sql, "api_doc", "swagger2")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"),
+		`@ApiModel(description = "订单")`,
+		`@ApiModelProperty(value = "名称", required = true)`,
+	)
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint PRIMARY KEY, amount decimal(10,2), created_at datetime) COMMENT='订单'"
+	docs := t.TempDir()
+	generate(t, sql, "orm", "mybatis-plus", "openapi_path", docs, "url_prefix", "/api")
+	spec := readGenerated(t, docs, "t-order.openapi.yaml")
+	assertContains(t, spec,
+		`title: "订单"`,
+		`"/api/t-order":`,
+		`"/api/t-order/{id}":`,
+		"$ref: '#/components/schemas/TOrderDO'",
+	)
+
+	// 配置了转换器时按 Controller 使用的请求/响应对象描述接口
+	java := filepath.Join(t.TempDir(), "src", "main", "java", "com", "demo")
+	docs = t.TempDir()
+	generate(t, sql, "orm", "mybatis-plus", "openapi_path", docs,
+		"create_request_path", filepath.Join(java, "model", "request"),
+		"update_request_path", filepath.Join(java, "model", "request"),
+		"query_path", filepath.Join(java, "model", "query"),
+		"vo_path", filepath.Join(java, "model", "vo"),
+		"converter_path", filepath.Join(java, "convert"),
+	)
+	spec = readGenerated(t, docs, "t-order.openapi.yaml")
+	assertContains(t, spec,
+		"$ref: '#/components/schemas/TOrderCreateRequest'",
+		"$ref: '#/components/schemas/TOrderUpdateRequest'",
+		"$ref: '#/components/schemas/TOrderVOPage'",
+		"items:\n            $ref: '#/components/schemas/TOrderVO'",
+		"- name: createdAtStart\n          in: query",
+		"    TOrderUpdateRequest:\n      type: object\n      required:\n        - id\n",
+	)
+	assertNotContains(t, spec, "TOrderDO", "TOrderQuery")
+}
+
+func TestGenerateTypeScript(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint AUTO_INCREMENT PRIMARY KEY, name varchar(64) NOT NULL COMMENT '名称 */ <b>', created_at datetime)"
+	web := t.TempDir()
+	generate(t, sql, "ts_path", web, "ts_client", "axios", "ts_long_as_string", "1")
+	assertContains(t, readGenerated(t, web, "t-order.ts"),
+		"export interface TOrder {",
+		"  id?: string;",
+		"   * 名称 *\\/ <b>\n   */\n  name: string;",
+		"  createdAt?: string;",
+	)
+	assertContains(t, readGenerated(t, web, "t-order.api.ts"),
+		"import axios from 'axios';",
+		"from './t-order';",
+	)
+
+	// 配置了转换器时请求函数使用与 Controller 一致的请求/响应类型
+	java := filepath.Join(t.TempDir(), "src", "main", "java", "com", "demo")
+	web = t.TempDir()
+	generate(t, sql, "ts_path", web, "ts_client", "fetch",
+		"create_request_path", filepath.Join(java, "model", "request"),
+		"update_request_path", filepath.Join(java, "model", "request"),
+		"query_path", filepath.Join(java, "model", "query"),
+		"vo_path", filepath.Join(java, "model", "vo"),
+		"converter_path", filepath.Join(java, "convert"),
+	)
+	types := readGenerated(t, web, "t-order.ts")
+	assertContains(t, types,
+		"export type TOrderPageParams = TOrderQuery;",
+		"export interface TOrderVOPage {\n  records: TOrderVO[];",
+	)
+	assertNotContains(t, types, "export interface TOrder {")
+	assertContains(t, readGenerated(t, web, "t-order.api.ts"),
+		"import type { TOrderCreateRequest, TOrderUpdateRequest, TOrderVO, TOrderPageParams, TOrderVOPage } from './t-order';",
+		"export function createTOrder(record: TOrderCreateRequest): Promise<TOrderVO> {",
+		"export function updateTOrder(record: TOrderUpdateRequest): Promise<boolean> {",
+		"export function getTOrder(id: number): Promise<TOrderVO> {",
+	)
+}
+
+func TestGenerateEnums(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint PRIMARY KEY, order_status tinyint COMMENT '订单状态：0-待支付，1-已支付', channel enum('web','app'))"
+	enums := filepath.Join(t.TempDir(), "src", "main", "java", "com", "demo", "enums")
+	root := generate(t, sql, "orm", "mybatis-plus", "enum_path", enums)
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"),
+		"import com.demo.enums.TOrderOrderStatusEnum;",
+		"private TOrderOrderStatusEnum orderStatus;",
+		"private TOrderChannelEnum channel;",
+	)
+	status := readGenerated(t, enums, "TOrderOrderStatusEnum.java")
+	assertContains(t, status,
+		"package com.demo.enums;",
+		"import com.baomidou.mybatisplus.annotation.EnumValue;",
+		" * 订单状态\n",
+		`VALUE_0(0, "待支付"),`,
+		`VALUE_1(1, "已支付");`,
+		"@EnumValue\n    private final Integer code;",
+	)
+	assertContains(t, readGenerated(t, enums, "TOrderChannelEnum.java"),
+		`WEB("web", "web"),`,
+		"private final String code;",
+	)
+}
+
+func TestGenerateJSONColumns(t *testing.T) {
+	sql := "CREATE TABLE t_doc (id bigint PRIMARY KEY, extra json COMMENT '扩展信息', tags json)"
+	root := generate(t, sql, "orm", "mybatis-plus", "json_type", "class", "xml_result_map", "1")
+	do := readGenerated(t, root, "java/com/demo/entity/TDocDO.java")
+	assertContains(t, do,
+		`@TableName(value = "t_doc", autoResultMap = true)`,
+		"@TableField(typeHandler = JacksonTypeHandler.class)\n    private TDocDO.Extra extra;",
+		"public static class Extra {",
+		"public static class Tags {",
+	)
+	assertContains(t, readGenerated(t, root, "resources/mapper/TDocMapper.xml"),
+		`<result column="extra" property="extra" jdbcType="OTHER" typeHandler="com.baomidou.mybatisplus.extension.handlers.JacksonTypeHandler"/>`,
+	)
+
+	root = generate(t, sql, "orm", "mybatis-flex", "json_type", "map")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/TDocDO.java"),
+		"@Column(typeHandler = JacksonTypeHandler.class)\n    private Map<String, Object> extra;",
+		"import com.mybatisflex.core.handler.JacksonTypeHandler;",
+	)
+}
+
+func TestGenerateTenant(t *testing.T) {
+	sql := `CREATE TABLE t_order (id bigint AUTO_INCREMENT PRIMARY KEY, tenant_id bigint NOT NULL, name varchar(64), version int);
+CREATE TABLE t_region (id bigint PRIMARY KEY, name varchar(64));`
+	web := filepath.Join(t.TempDir(), "src", "main")
+	java := filepath.Join(web, "java", "com", "demo", "model")
+	root := generate(t, sql,
+		"orm", "mybatis-plus",
+		"tenant_column", "tenant_id",
+		"version_columns", "version",
+		"create_request_path", java,
+		"update_request_path", java,
+		"query_path", java,
+	)
+	assertContains(t, readGenerated(t, root, "java/com/demo/dao/config/MybatisPlusConfig.java"),
+		"package com.demo.dao.config;",
+		`private static final Set<String> IGNORE_TABLES = Set.of("t_region");`,
+		"return new LongValue(currentTenantId());",
+		`return "tenant_id";`,
+		"new OptimisticLockerInnerInterceptor()",
+	)
+	// 租户列由租户插件维护，不能由客户端提交
+	for _, class := range []string{"TOrderCreateRequest", "TOrderUpdateRequest", "TOrderQuery"} {
+		assertNotContains(t, readGenerated(t, web, "java/com/demo/model/"+class+".java"), "tenantId")
+	}
+
+	root = generate(t, sql, "orm", "mybatis-flex", "tenant_column", "tenant_id")
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"),
+		"@Column(tenantId = true)\n    private Long tenantId;",
+	)
+}
+
+func TestGenerateColumnRulesForAllORMs(t *testing.T) {
+	sql := "CREATE TABLE t_order (id bigint AUTO_INCREMENT PRIMARY KEY, tenant_id bigint, name varchar(64), deleted tinyint(1), create_time datetime)"
+	for _, orm := range []string{"mybatis", "tk-mybatis", "mybatis-dynamic-sql", "jpa"} {
+		t.Run(orm, func(t *testing.T) {
+			web := filepath.Join(t.TempDir(), "src", "main")
+			java := filepath.Join(web, "java", "com", "demo")
+			root := generate(t, sql,
+				"orm", orm,
+				"tenant_column", "tenant_id",
+				"logic_delete_columns", "deleted",
+				"insert_fill_columns", "create_time",
+				"create_request_path", filepath.Join(java, "model"),
+				"update_request_path", filepath.Join(java, "model"),
+				"converter_path", filepath.Join(java, "convert"),
+			)
+			// 约定字段不出现在请求对象中，转换时显式忽略
+			for _, class := range []string{"TOrderCreateRequest", "TOrderUpdateRequest"} {
+				assertNotContains(t, readGenerated(t, web, "java/com/demo/model/"+class+".java"), "tenantId", "deleted", "createTime")
+			}
+			assertContains(t, readGenerated(t, web, "java/com/demo/convert/TOrderConverter.java"),
+				"@Mapping(target = \"tenantId\", ignore = true)",
+				"@Mapping(target = \"deleted\", ignore = true)",
+			)
+			// MyBatis-Plus 的注解不会出现在其他 ORM 的 DO 中
+			assertNotContains(t, readGenerated(t, root, "java/com/demo/entity/TOrderDO.java"), "baomidou", "FieldFill")
+		})
+	}
+}
+
+func TestGenerateShardRules(t *testing.T) {
+	sql := `CREATE TABLE order_0 (id bigint PRIMARY KEY, amount decimal(10,2));
+CREATE TABLE order_1 (id bigint PRIMARY KEY, amount decimal(10,2));
+CREATE TABLE order_2 (id bigint PRIMARY KEY, amount decimal(10,2));`
+	rules := t.TempDir()
+	root := generate(t, sql, "orm", "mybatis-plus", "shard_merge", "1", "shard_rule", "shardingsphere", "sharding_path", rules)
+	assertContains(t, readGenerated(t, root, "java/com/demo/entity/OrderDO.java"),
+		"public class OrderDO {",
+		"@TableName(\"`order`\")",
+	)
+	assertContains(t, readGenerated(t, rules, "sharding.yaml"),
+		"      order:\n        actualDataNodes: \"ds_0.order_${0..2}\"",
+		"shardingColumn: id",
+		`algorithm-expression: "order_${id % 3}"`,
+	)
+
+	// order 是保留字，SQL 中的表名带引号
+	root = generate(t, sql, "orm", "mybatis-plus", "shard_merge", "1", "shard_rule", "dynamic-table")
+	assertContains(t, readGenerated(t, root, "java/com/demo/dao/config/ShardTableNameHandler.java"),
+		`private static final Set<String> LOGIC_TABLES = Set.of("order");`,
+		`String name = tableName.replace("`+"`"+`", "").replace("\"", "");`,
+		`return tableName.replace(name, name + "_" + suffix);`,
+	)
+}
@@ -1,65 +1,549 @@
 package model
 
-import "github.com/iancoleman/strcase"
+import (
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+)
 
 // Field 表示数据库表的字段信息
 type Field struct {
-	Name     string // 字段名 (原始名称)
-	Type     string // SQL 类型
-	JavaType string // 对应的 Java 类型
-	Comment  string // 字段注释
-	IsId     bool   // 是否为主键ID字段
+	Name          string       `json:"name"`                    // 字段名 (原始名称)
+	Type          string       `json:"type"`                    // SQL 类型
+	JavaType      string       `json:"javaType"`                // 对应的 Java 类型
+	Comment       string       `json:"comment"`                 // 字段注释
+	IsId          bool         `json:"isId"`                    // 是否为主键ID字段
+	NotNull       bool         `json:"notNull,omitempty"`       // 是否有 NOT NULL 约束
+	HasDefault    bool         `json:"hasDefault,omitempty"`    // 是否有默认值 (含自增、serial、identity)
+	AutoIncrement bool         `json:"autoIncrement,omitempty"` // 是否由数据库自增生成 (AUTO_INCREMENT、serial、identity)
+	EnumValues    []string     `json:"enumValues,omitempty"`    // ENUM 类型的取值
+	TypeHandler   string       `json:"typeHandler,omitempty"`   // 字段使用的 TypeHandler 全限定名，如 JSON 列的 JacksonTypeHandler
+	Length        int          `json:"length,omitempty"`        // 字符类型的长度
+	Precision     int          `json:"precision,omitempty"`     // 数值类型的精度
+	Scale         int          `json:"scale,omitempty"`         // 数值类型的小数位数
+	PropertyName  string       `json:"propertyName,omitempty"`  // Java 属性名，为空时由字段名转换得到
+	MappedColumn  string       `json:"mappedColumn,omitempty"`  // 需要通过注解显式映射的列名，为空表示可依赖驼峰自动映射
+	JdbcType      string       `json:"jdbcType,omitempty"`      // MyBatis JdbcType，仅模板字段
+	KotlinType    string       `json:"kotlinType,omitempty"`    // Kotlin 类型 (不含可空标记)，仅模板字段
+	KeyPart       bool         `json:"keyPart,omitempty"`       // 是否为复合主键的一部分，仅模板字段
+	Identity      bool         `json:"identity,omitempty"`      // 是否为自增的单列数值主键 (JPA IDENTITY)，仅模板字段
+	LogicDelete   bool         `json:"logicDelete,omitempty"`   // 逻辑删除字段
+	Version       bool         `json:"version,omitempty"`       // 乐观锁版本字段
+	Fill          FillStrategy `json:"fill,omitempty"`          // 自动填充策略
+	Tenant        bool         `json:"tenant,omitempty"`        // 多租户字段，由租户插件读写，不出现在请求对象中
+	APIDoc        string       `json:"apiDoc,omitempty"`        // OpenAPI/Swagger 字段注解 (不含 @)，仅模板字段
+}
+
+// Column 返回在 SQL 中引用该字段时使用的列名 (必要时带引号)
+func (f Field) Column() string {
+	if f.MappedColumn != "" {
+		return f.MappedColumn
+	}
+	return f.Name
 }
 
 // TableInfo 表示表的信息
 type TableInfo struct {
-	TableName string  // 表名
-	Fields    []Field // 字段列表
+	TableName   string       `json:"tableName"`             // 表名
+	DbType      string       `json:"dbType"`                // 数据库类型 mysql/postgresql
+	Comment     string       `json:"comment,omitempty"`     // 表注释
+	Fields      []Field      `json:"fields"`                // 字段列表
+	ForeignKeys []ForeignKey `json:"foreignKeys,omitempty"` // 外键约束
+	Shard       *ShardFamily `json:"shard,omitempty"`       // 由分表合并得到的逻辑表，TableName 为逻辑表名
+}
+
+// ShardFamily 描述列相同、仅表名后缀不同的一组分表，如 order_0 ~ order_63、log_202401 ~ log_202412
+type ShardFamily struct {
+	LogicTable string   `json:"logicTable"`           // 去掉后缀的逻辑表名，如 order
+	Suffixes   []string `json:"suffixes"`             // 各分表的后缀，按数值升序
+	DateFormat string   `json:"dateFormat,omitempty"` // 日期后缀的格式 yyyyMM 或 yyyyMMdd，为空表示数字后缀
+}
+
+// ShardRule 决定为分表生成的路由配置
+type ShardRule string
+
+const (
+	ShardRuleShardingSphere ShardRule = "shardingsphere" // ShardingSphere-JDBC 分片规则 YAML
+	ShardRuleDynamicTable   ShardRule = "dynamic-table"  // MyBatis-Plus DynamicTableNameInnerInterceptor 的表名处理器
+)
+
+// SupportedShardRule 判断分表路由配置是否受支持
+func SupportedShardRule(r ShardRule) bool {
+	return r == "" || r == ShardRuleShardingSphere || r == ShardRuleDynamicTable
+}
+
+// ForeignKey 表示外键约束，RefColumns 为空时引用目标表的主键
+type ForeignKey struct {
+	Columns    []string `json:"columns"`              // 本表的外键列
+	RefTable   string   `json:"refTable"`             // 引用的表
+	RefColumns []string `json:"refColumns,omitempty"` // 引用表中的列
 }
 
-// ToTemplateFields 将字段名转换为小驼峰命名法，用于模板渲染
-func (ti *TableInfo) ToTemplateFields() []Field {
+// ToTemplateFields 为每个字段计算小驼峰命名的属性名，用于模板渲染
+func (ti *TableInfo) ToTemplateFields(naming NamingConfig) []Field {
 
 	newFields := make([]Field, len(ti.Fields))
 	for i, field := range ti.Fields {
-		newFields[i] = Field{
-			Name:     strcase.ToLowerCamel(field.Name),
-			Type:     field.Type,
-			JavaType: field.JavaType,
-			Comment:  field.Comment,
-			IsId:     field.IsId,
+		newFields[i] = field
+		if field.PropertyName == "" {
+			newFields[i].PropertyName = naming.PropertyName(field)
 		}
 	}
 	return newFields
 }
 
+// PropertyRename 记录因不是合法 Java 标识符或与其他列重名而被改名的属性
+type PropertyRename struct {
+	Column string `json:"column"` // 列名
+	From   string `json:"from"`   // 原属性名
+	To     string `json:"to"`     // 改名后的属性名
+	Reason string `json:"reason"` // 改名原因
+}
+
 // TemplateData 是传递给Go模板的最终数据结构
 type TemplateData struct {
-	ORM                ORM
-	DOClassName        string
-	MapperClassName    string
-	DAOClassName       string
-	DAOImplClassName   string
-	MapperVarName      string
-	TableName          string
-	Fields             []Field
-	DOPackage          string
-	MapperPackage      string
-	DAOPackage         string
-	DAOImplPackage     string
-	Imports            []string
-	MapperNamespace    string
-	MybatisPlusImports []string
-}
-
-// PathConfig 存储用户提供的所有路径
+	ORM                ORM              `json:"orm"`
+	Language           Language         `json:"language"`
+	DOClassName        string           `json:"doClassName"`
+	EntityName         string           `json:"entityName"`
+	MapperClassName    string           `json:"mapperClassName"`
+	DAOClassName       string           `json:"daoClassName"`
+	DAOImplClassName   string           `json:"daoImplClassName"`
+	MapperVarName      string           `json:"mapperVarName"`
+	TableName          string           `json:"tableName"`
+	TableComment       string           `json:"tableComment,omitempty"`
+	SQLTableName       string           `json:"sqlTableName"`
+	Fields             []Field          `json:"fields"`
+	DOFields           []Field          `json:"doFields"`
+	IdFields           []Field          `json:"idFields"`
+	IdType             string           `json:"idType"`
+	DOPackage          string           `json:"doPackage"`
+	MapperPackage      string           `json:"mapperPackage"`
+	DAOPackage         string           `json:"daoPackage"`
+	DAOImplPackage     string           `json:"daoImplPackage"`
+	Imports            []string         `json:"imports"`
+	MapperNamespace    string           `json:"mapperNamespace"`
+	MybatisPlusImports []string         `json:"mybatisPlusImports"`
+	Renames            []PropertyRename `json:"renames,omitempty"`
+	XML                XMLConfig        `json:"xml"`
+	DO                 DOConfig         `json:"do"`
+	DOAnnotations      []string         `json:"doAnnotations,omitempty"`
+	LombokImports      []string         `json:"lombokImports,omitempty"`
+	FillFields         []Field          `json:"fillFields,omitempty"`
+	BaseClassName      string           `json:"baseClassName,omitempty"`
+	BaseDO             *TemplateData    `json:"baseDO,omitempty"`
+	Associations       []Association    `json:"associations,omitempty"`
+	VOPackage          string           `json:"voPackage,omitempty"`
+	Service            *ServiceData     `json:"service,omitempty"`
+	Models             []ModelClass     `json:"models,omitempty"`
+	Converter          *ConverterData   `json:"converter,omitempty"`
+	OpenAPI            *OpenAPIData     `json:"openAPI,omitempty"`
+	TypeScript         *TypeScriptData  `json:"typeScript,omitempty"`
+	Enums              []EnumClass      `json:"enums,omitempty"`
+	AutoResultMap      bool             `json:"autoResultMap,omitempty"` // 有字段使用 TypeHandler 时 @TableName 需要 autoResultMap
+	NestedClasses      []NestedClass    `json:"nestedClasses,omitempty"` // DO 中为 JSON 列生成的静态内部类
+}
+
+// PluginConfigData 是 MyBatis-Plus 插件配置类的模板数据，由同批生成的所有表汇总得到
+type PluginConfigData struct {
+	Package          string   `json:"package"`
+	TenantColumn     string   `json:"tenantColumn,omitempty"`     // 为空表示没有表包含租户列，不添加租户插件
+	TenantType       string   `json:"tenantType,omitempty"`       // 租户列的 Java 类型
+	IgnoreTables     []string `json:"ignoreTables,omitempty"`     // 不包含租户列的表，租户插件跳过这些表
+	DbType           string   `json:"dbType"`                     // 分页插件的 DbType 枚举值，如 MYSQL
+	OptimisticLocker bool     `json:"optimisticLocker,omitempty"` // 有乐观锁字段时添加乐观锁插件
+	DynamicTableName bool     `json:"dynamicTableName,omitempty"` // 注册分表的动态表名插件
+}
+
+// NestedClass 是 DO 中的静态内部类
+type NestedClass struct {
+	ClassName string `json:"className"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// JSONType 决定 JSON/JSONB 列映射的 Java 类型，为空时保持 String
+type JSONType string
+
+const (
+	JSONTypeMap      JSONType = "map"      // Map<String, Object>
+	JSONTypeJsonNode JSONType = "jsonnode" // Jackson JsonNode
+	JSONTypeClass    JSONType = "class"    // DO 中生成的静态内部类
+)
+
+// SupportedJSONType 判断 JSON 列的映射方式是否受支持
+func SupportedJSONType(t JSONType) bool {
+	return t == "" || t == JSONTypeMap || t == JSONTypeJsonNode || t == JSONTypeClass
+}
+
+// ConverterData 是 MapStruct 转换器模板需要的数据，未生成的对象对应的类名为空
+type ConverterData struct {
+	ClassName     string   `json:"className"`
+	Package       string   `json:"package"`
+	Imports       []string `json:"imports"`
+	VOClass       string   `json:"voClass,omitempty"`
+	CreateClass   string   `json:"createClass,omitempty"`
+	UpdateClass   string   `json:"updateClass,omitempty"`
+	QueryClass    string   `json:"queryClass,omitempty"`
+	CreateIgnores []string `json:"createIgnores,omitempty"` // 从新增请求映射时忽略的 DO 属性 (主键、审计字段等)
+	UpdateIgnores []string `json:"updateIgnores,omitempty"` // 从修改请求映射时忽略的 DO 属性
+	QueryIgnores  []string `json:"queryIgnores,omitempty"`  // 从查询条件映射时忽略的 DO 属性 (时间范围、逻辑删除等)
+}
+
+// EnumClass 是由 ENUM 类型或列注释推导出的枚举类，DO 中对应字段的类型为该枚举
+type EnumClass struct {
+	ClassName       string         `json:"className"`
+	Package         string         `json:"package"`
+	Comment         string         `json:"comment"`
+	Property        string         `json:"property"`        // 使用该枚举的 DO 属性
+	CodeType        string         `json:"codeType"`        // code 的类型，与列原本的 Java 类型一致
+	EnumValueImport string         `json:"enumValueImport"` // 各 ORM 的 @EnumValue 注解
+	Constants       []EnumConstant `json:"constants"`
+}
+
+// EnumConstant 是枚举的一个取值
+type EnumConstant struct {
+	Name string `json:"name"`
+	Code string `json:"code"` // Java 字面量，如 1、"ORDER_CREATE"
+	Desc string `json:"desc"`
+}
+
+// OpenAPIData 是 openapi.yaml 模板需要的数据，描述 Controller 的增删改查接口，未配置 OpenAPI 路径时为空
+type OpenAPIData struct {
+	FileName     string            `json:"fileName"`
+	Title        string            `json:"title"` // 文档标题，取表注释，没有注释时取表名
+	RequestPath  string            `json:"requestPath"`
+	Schemas      []OpenAPISchema   `json:"schemas"`      // 接口用到的 DO 及请求/响应对象
+	CreateSchema string            `json:"createSchema"` // 新增接口请求体的 schema 名
+	UpdateSchema string            `json:"updateSchema"` // 修改接口请求体的 schema 名
+	ViewSchema   string            `json:"viewSchema"`   // 查询接口返回的 schema 名
+	QueryParams  []OpenAPIProperty `json:"queryParams"`  // 分页查询的条件参数，不含二进制字段
+	Id           *OpenAPIProperty  `json:"id,omitempty"` // 单列主键，没有时不生成 /{id} 接口
+	PageSchema   string            `json:"pageSchema"`
+	PageRecords  string            `json:"pageRecords"`            // 分页结果中数据列表的属性名，如 records、list
+	PageCounts   []string          `json:"pageCounts"`             // 分页结果中的页码、总数等整型属性
+	ResultSchema string            `json:"resultSchema,omitempty"` // 响应包装类的 schema 名，为空时直接返回数据
+	ResultClass  string            `json:"resultClass,omitempty"`
+}
+
+// OpenAPISchema 是 components.schemas 中的一个对象
+type OpenAPISchema struct {
+	Name       string            `json:"name"`
+	Required   []string          `json:"required,omitempty"`
+	Properties []OpenAPIProperty `json:"properties"`
+}
+
+// OpenAPIProperty 是 schema 中的一个属性，由 Field 推导
+type OpenAPIProperty struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Format      string   `json:"format,omitempty"`
+	Items       string   `json:"items,omitempty"` // 数组元素的类型
+	Enum        []string `json:"enum,omitempty"`
+	MaxLength   int      `json:"maxLength,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// TypeScriptData 是前端 TypeScript 类型和接口请求模板需要的数据，未配置 TypeScript 路径时为空
+type TypeScriptData struct {
+	FileName       string        `json:"fileName"`                 // 类型定义文件名，如 order-item.ts
+	ClientFileName string        `json:"clientFileName,omitempty"` // 接口请求文件名，未选择请求库时为空
+	Module         string        `json:"module"`                   // 接口请求文件导入类型时使用的模块路径，如 ./order-item
+	Client         TSClient      `json:"client,omitempty"`
+	Interfaces     []TSInterface `json:"interfaces"`      // 接口用到的 DO 及请求/响应对象对应的 interface
+	Entity         string        `json:"entity"`          // DO 对应的 interface 名，也用于请求函数的命名
+	Create         string        `json:"create"`          // 新增请求体的类型，与 Controller 一致
+	Update         string        `json:"update"`          // 修改请求体的类型
+	View           string        `json:"view"`            // 查询结果中单条数据的类型
+	Query          string        `json:"query,omitempty"` // 分页查询条件的类型，为空时以 DO 的属性作为等值条件
+	Imports        []string      `json:"imports"`         // 接口请求文件从类型定义文件导入的类型
+	Page           string        `json:"page"`
+	PageRecords    string        `json:"pageRecords"`
+	PageCounts     []string      `json:"pageCounts"`
+	RequestPath    string        `json:"requestPath"`
+	IdType         string        `json:"idType,omitempty"` // 单列主键的类型，没有时不生成按主键的请求
+	Result         string        `json:"result,omitempty"` // 响应包装类型名，为空时直接返回数据
+}
+
+// TSInterface 是一个 TypeScript interface
+type TSInterface struct {
+	Name       string       `json:"name"`
+	Comment    string       `json:"comment,omitempty"`
+	Properties []TSProperty `json:"properties"`
+}
+
+// TSProperty 是 interface 中的一个属性，可空的列生成可选属性
+type TSProperty struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// TSClient 是生成的前端接口请求使用的库，为空时只生成类型
+type TSClient string
+
+const (
+	TSClientAxios TSClient = "axios"
+	TSClientFetch TSClient = "fetch"
+)
+
+// SupportedTSClient 判断前端请求库是否受支持
+func SupportedTSClient(client TSClient) bool {
+	return client == "" || client == TSClientAxios || client == TSClientFetch
+}
+
+// TypeScriptConfig 控制前端 TypeScript 代码的生成
+type TypeScriptConfig struct {
+	Client       TSClient `json:"client,omitempty"`
+	LongAsString bool     `json:"longAsString,omitempty"` // Long 映射为 string，避免超过 2^53 的 ID 在 JS 中丢失精度
+}
+
+// APIDocStyle 是 DO 和请求/响应对象上的接口文档注解风格，为空时不生成
+type APIDocStyle string
+
+const (
+	APIDocSpringdoc APIDocStyle = "springdoc" // @Schema
+	APIDocSwagger2  APIDocStyle = "swagger2"  // @ApiModel/@ApiModelProperty
+)
+
+// SupportedAPIDoc 判断接口文档注解风格是否受支持
+func SupportedAPIDoc(style APIDocStyle) bool {
+	return style == "" || style == APIDocSpringdoc || style == APIDocSwagger2
+}
+
+// ModelKind 是由表结构派生的请求/响应对象种类
+type ModelKind string
+
+const (
+	ModelCreateRequest ModelKind = "create"
+	ModelUpdateRequest ModelKind = "update"
+	ModelQuery         ModelKind = "query"
+	ModelVO            ModelKind = "vo"
+)
+
+// ModelClass 描述一个由表结构派生的对象，如 XxxCreateRequest、XxxQuery
+type ModelClass struct {
+	Kind        ModelKind    `json:"kind"`
+	ClassName   string       `json:"className"`
+	Package     string       `json:"package"`
+	Comment     string       `json:"comment"`
+	Annotations []string     `json:"annotations,omitempty"` // 类上的注解 (不含 @)
+	Imports     []string     `json:"imports"`
+	Fields      []ModelField `json:"fields"`
+}
+
+// ModelField 是请求/响应对象中的一个属性
+type ModelField struct {
+	Name        string   `json:"name"`
+	JavaType    string   `json:"javaType"`
+	Comment     string   `json:"comment,omitempty"`
+	Annotations []string `json:"annotations,omitempty"` // 校验等注解 (不含 @)
+	Default     string   `json:"default,omitempty"`     // 属性初始值
+	RangeOf     string   `json:"rangeOf,omitempty"`     // Query 中时间范围的起止属性对应的 DO 属性
+}
+
+// ServiceData 是 Service、ServiceImpl 和 Controller 模板需要的数据，未配置 Service 路径时为空
+type ServiceData struct {
+	ServiceClassName     string `json:"serviceClassName"`
+	ServiceImplClassName string `json:"serviceImplClassName"`
+	ControllerClassName  string `json:"controllerClassName"`
+	ServiceVarName       string `json:"serviceVarName"`
+	DAOVarName           string `json:"daoVarName"`
+	ServicePackage       string `json:"servicePackage"`
+	ServiceImplPackage   string `json:"serviceImplPackage"`
+	ControllerPackage    string `json:"controllerPackage"`
+	PageType             string `json:"pageType"`                  // 分页结果类型，如 IPage、Page、PageInfo
+	PageImport           string `json:"pageImport"`                // 分页结果类型的导入
+	RequestPath          string `json:"requestPath"`               // Controller 的 @RequestMapping 路径
+	ResultClassName      string `json:"resultClassName,omitempty"` // 响应包装类的简单类名
+	ResultMethod         string `json:"resultMethod,omitempty"`
+	ResultImport         string `json:"resultImport,omitempty"`
+
+	// 配置了转换器时 Service/Controller 通过请求/响应对象读写，对应的类名为空时直接使用 DO
+	ConverterClassName string       `json:"converterClassName,omitempty"`
+	ConverterVarName   string       `json:"converterVarName,omitempty"`
+	ConverterImport    string       `json:"converterImport,omitempty"`
+	CreateClass        string       `json:"createClass,omitempty"`
+	UpdateClass        string       `json:"updateClass,omitempty"`
+	QueryClass         string       `json:"queryClass,omitempty"`
+	VOClass            string       `json:"voClass,omitempty"`
+	ModelImports       []string     `json:"modelImports,omitempty"` // 请求/响应对象的导入
+	IdProperty         string       `json:"idProperty,omitempty"`   // 修改请求中定位记录的主键属性
+	Ranges             []QueryRange `json:"ranges,omitempty"`       // Query 中的时间范围条件
+}
+
+// QueryRange 是 Query 中由时间字段拆分出的起止范围
+type QueryRange struct {
+	Property string `json:"property"` // DO 中的属性，如 createTime
+	Start    string `json:"start"`    // Query 中的起始属性，如 createTimeStart
+	End      string `json:"end"`
+}
+
+// Association 描述与同批生成的另一张表之间的关联，由外键推导得到
+// Many 为 true 表示一对多 (被引用的一方)，否则为多对一 (持有外键的一方)
+type Association struct {
+	Many              bool    `json:"many"`
+	Property          string  `json:"property"`          // 关联对象在 VO/DO 中的属性名，如 items、order
+	JavaType          string  `json:"javaType"`          // 关联属性的类型，如 List<OrderItemDO>
+	VOClassName       string  `json:"voClassName"`       // 聚合 VO 类名，如 OrderWithItemsVO
+	Method            string  `json:"method"`            // Mapper 中的关联查询方法，如 selectWithItemsById
+	ResultMapID       string  `json:"resultMapId"`       // 关联查询使用的 resultMap
+	ColumnPrefix      string  `json:"columnPrefix"`      // 关联表列在查询结果中的别名前缀
+	SelfColumn        string  `json:"selfColumn"`        // 本表的连接列 (SQL 中使用的列名)
+	SelfProperty      string  `json:"selfProperty"`      // 本表连接列对应的属性
+	TargetTable       string  `json:"targetTable"`       // 关联表在 SQL 中使用的表名
+	TargetColumn      string  `json:"targetColumn"`      // 关联表的连接列
+	TargetProperty    string  `json:"targetProperty"`    // 关联表连接列对应的属性
+	TargetDOClassName string  `json:"targetDOClassName"` // 关联表的 DO 类名
+	TargetDOPackage   string  `json:"targetDOPackage"`
+	TargetFields      []Field `json:"targetFields"` // 关联表的字段，用于生成嵌套 resultMap
+}
+
+// PathConfig 存储用户提供的所有路径及生成选项
 type PathConfig struct {
-	DOPath      string
-	MapperPath  string
-	DAOPath     string
-	DAOImplPath string
-	XMLPath     string
-	ORM         ORM
+	DOPath            string
+	MapperPath        string
+	DAOPath           string
+	DAOImplPath       string
+	XMLPath           string
+	ServicePath       string // 以下三个路径为空时不生成对应的类
+	ServiceImplPath   string
+	ControllerPath    string
+	CreateRequestPath string // 以下四个路径为空时不生成对应的请求/响应对象
+	UpdateRequestPath string
+	QueryPath         string
+	VOPath            string
+	ConverterPath     string // MapStruct 转换器路径，为空时不生成
+	APIDoc            APIDocStyle
+	OpenAPIPath       string // openapi.yaml 输出目录，为空时不生成
+	TypeScriptPath    string // 前端 TypeScript 代码输出目录，为空时不生成
+	EnumPath          string // 枚举类输出目录，为空时不生成枚举
+	EnumRegex         string // 从列注释中匹配枚举项的正则，需包含 code 和 desc 两个命名分组，为空时使用默认规则
+	JSONType          JSONType
+	PluginConfig      bool // 生成 MyBatis-Plus 插件配置类 MybatisPlusConfig
+	ShardMerge        bool // 将列相同、仅数字或日期后缀不同的表合并为一个逻辑表
+	ShardRule         ShardRule
+	ShardingPath      string // ShardingSphere 分片规则 sharding.yaml 的输出目录
+	TypeScript        TypeScriptConfig
+	ORM               ORM
+	Language          Language
+	Naming            NamingConfig
+	XML               XMLConfig
+	DO                DOConfig
+	ColumnRules       ColumnRules
+	BaseDO            BaseDOConfig
+	Web               WebConfig
+}
+
+// WebConfig 控制 Service/Controller 的生成
+type WebConfig struct {
+	ResultClass  string `json:"resultClass,omitempty"`  // 响应包装类的全限定名，如 com.demo.common.Result，为空时直接返回数据
+	ResultMethod string `json:"resultMethod,omitempty"` // 包装成功结果的静态方法，如 success
+	URLPrefix    string `json:"urlPrefix,omitempty"`    // Controller 路径前缀，如 /api
+}
+
+// XMLConfig 控制 mapper.xml 中生成的内容
+type XMLConfig struct {
+	ResultMap bool `json:"resultMap"` // 生成 BaseResultMap 和 Base_Column_List
+	CRUD      bool `json:"crud"`      // 生成 insertSelective、updateByPrimaryKeySelective、batchInsert 和 selectByCondition
+}
+
+// FillStrategy 对应 MyBatis-Plus 的 FieldFill
+type FillStrategy string
+
+const (
+	FillInsert       FillStrategy = "INSERT"
+	FillInsertUpdate FillStrategy = "INSERT_UPDATE"
+)
+
+// ColumnRules 按列名约定标记逻辑删除、乐观锁、自动填充和多租户字段，列名不区分大小写，
+// 请求/响应对象据此排除这些列，MyBatis-Plus 和 MyBatis-Flex 还会为其添加注解
+type ColumnRules struct {
+	LogicDelete []string // 逻辑删除列，如 deleted
+	Version     []string // 乐观锁版本列，如 version
+	InsertFill  []string // 插入时填充的列，如 create_time、create_by
+	UpdateFill  []string // 插入和更新时填充的列，如 update_time、update_by
+	Tenant      string   // 多租户列，如 tenant_id，为空表示不启用
+}
+
+// BaseDOConfig 描述所有 DO 共同继承的父类，父类中的列不会再出现在各 DO 中
+type BaseDOConfig struct {
+	ClassName string   // 父类类名，如 BaseDO，为空表示不使用父类
+	Package   string   // 父类所在包，为空时与 DO 相同
+	Columns   []string // 父类中声明的列，如 create_time、update_time
+}
+
+// DOStyle 决定 DO 类的写法
+type DOStyle string
+
+const (
+	DOStyleLombok DOStyle = "lombok" // 使用 Lombok 注解
+	DOStylePlain  DOStyle = "plain"  // 不使用 Lombok，显式生成 getter/setter 和 toString
+	DOStyleRecord DOStyle = "record" // Java 16+ record，适合只读投影
+)
+
+// DOConfig 控制 DO 类使用的 Lombok 注解或写法
+type DOConfig struct {
+	Style             DOStyle `json:"style"`
+	Data              bool    `json:"data"`              // @Data
+	GetterSetter      bool    `json:"getterSetter"`      // @Getter @Setter
+	Builder           bool    `json:"builder"`           // @Builder @NoArgsConstructor @AllArgsConstructor
+	Chain             bool    `json:"chain"`             // @Accessors(chain = true)，plain 风格下 setter 返回 this
+	EqualsAndHashCode bool    `json:"equalsAndHashCode"` // @EqualsAndHashCode
+	CallSuper         bool    `json:"callSuper"`         // @EqualsAndHashCode 是否包含父类字段
+}
+
+// SupportedDOStyle 判断该 ORM 是否支持指定的 DO 写法
+// record 没有 setter，只用于依赖自动映射的 MyBatis-Plus 和 MyBatis-Flex 只读查询
+func SupportedDOStyle(style DOStyle, orm ORM) bool {
+	switch style {
+	case DOStyleLombok, DOStylePlain:
+		return true
+	case DOStyleRecord:
+		return orm == ORMMyBatisPlus || orm == ORMMyBatisFlex
+	}
+	return false
+}
+
+// NamingConfig 控制如何由表名推导类名、由列名推导属性名
+type NamingConfig struct {
+	TablePrefixes      []string // 需要去除的表名前缀，如 t_、tb_、sys_
+	TableSuffixes      []string // 需要去除的表名后缀
+	TableRegex         string   // 表名中匹配该正则的部分会被去除
+	DOPattern          string   // DO 类名模式，如 {{Camel}}Entity
+	MapperPattern      string   // Mapper 类名模式
+	DAOPattern         string   // DAO 类名模式，如 I{{Camel}}Service
+	DAOImplPattern     string   // DAO 实现类名模式
+	ServicePattern     string   // Service 类名模式
+	ServiceImplPattern string   // Service 实现类名模式
+	ControllerPattern  string   // Controller 类名模式
+	ConverterPattern   string   // MapStruct 转换器类名模式
+	ColumnPrefixes     []string // 需要去除的列名前缀，如 f_
+	PropertyPrefix     string   // 属性名以数字开头或为空时添加的前缀
+	PropertySuffix     string   // 属性名为 Java 关键字时添加的后缀
+}
+
+// PropertyName 由列名推导 Java 属性名
+// 会去除配置的列名前缀，并将 Boolean 字段的 is 前缀去掉 (is_deleted -> deleted)，
+// 避免 Lombok 生成的 getter 与 MyBatis 推断的属性名不一致；与其他列重名时由生成器恢复前缀或追加序号
+func (n NamingConfig) PropertyName(field Field) string {
+	column := field.Name
+	for _, prefix := range n.ColumnPrefixes {
+		if prefix != "" && len(column) > len(prefix) && strings.HasPrefix(strings.ToLower(column), strings.ToLower(prefix)) {
+			column = column[len(prefix):]
+			break
+		}
+	}
+
+	name := strcase.ToLowerCamel(column)
+	if field.JavaType == "Boolean" && len(name) > 2 && strings.HasPrefix(name, "is") {
+		if rest := []rune(name[2:]); unicode.IsUpper(rest[0]) {
+			rest[0] = unicode.ToLower(rest[0])
+			name = string(rest)
+		}
+	}
+	return name
 }
 
 type ORM string
@@ -67,15 +551,62 @@ type ORM string
 const (
 	ORMMyBatisPlus ORM = "mybatis-plus"
 	ORMMyBatisFlex ORM = "mybatis-flex"
+	ORMMyBatis     ORM = "mybatis"
+	ORMTkMybatis   ORM = "tk-mybatis"
+	ORMDynamicSQL  ORM = "mybatis-dynamic-sql"
+	ORMJPA         ORM = "jpa"
 )
 
-func Path(ORM ORM) string {
+// Language 生成代码所用的语言
+type Language string
+
+const (
+	LanguageJava   Language = "java"
+	LanguageKotlin Language = "kotlin"
+)
+
+// SupportedLanguage 判断该 ORM 是否支持生成指定语言的代码，Kotlin 目前只支持 MyBatis-Plus 和 MyBatis-Flex
+func SupportedLanguage(lang Language, orm ORM) bool {
+	switch lang {
+	case LanguageJava:
+		return true
+	case LanguageKotlin:
+		return orm == ORMMyBatisPlus || orm == ORMMyBatisFlex
+	}
+	return false
+}
+
+// SupportedORM 判断是否为支持的 ORM
+func SupportedORM(orm ORM) bool {
+	switch orm {
+	case ORMMyBatisPlus, ORMMyBatisFlex, ORMMyBatis, ORMTkMybatis, ORMDynamicSQL, ORMJPA:
+		return true
+	}
+	return false
+}
+
+func Path(ORM ORM, lang Language) string {
+	if lang == LanguageKotlin {
+		return "templates/kotlin/" + string(ORM)
+	}
 	if ORM == ORMMyBatisPlus {
 		return "templates/mybatis-plus"
 	}
 	if ORM == ORMMyBatisFlex {
 		return "templates/mybatis-flex"
 	}
+	if ORM == ORMMyBatis {
+		return "templates/mybatis"
+	}
+	if ORM == ORMTkMybatis {
+		return "templates/tk-mybatis"
+	}
+	if ORM == ORMDynamicSQL {
+		return "templates/mybatis-dynamic-sql"
+	}
+	if ORM == ORMJPA {
+		return "templates/jpa"
+	}
 	return "templates/mybatis-plus"
 
 }
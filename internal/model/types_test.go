@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestNamingConfigPropertyName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		field    Field
+		want     string
+	}{
+		{"驼峰", nil, Field{Name: "user_name", JavaType: "String"}, "userName"},
+		{"Boolean 去除 is 前缀", nil, Field{Name: "is_deleted", JavaType: "Boolean"}, "deleted"},
+		{"非 Boolean 保留 is 前缀", nil, Field{Name: "is_deleted", JavaType: "Integer"}, "isDeleted"},
+		{"is 后不是单词边界", nil, Field{Name: "issue", JavaType: "Boolean"}, "issue"},
+		{"只有 is", nil, Field{Name: "is", JavaType: "Boolean"}, "is"},
+		{"去除列名前缀", []string{"f_"}, Field{Name: "F_user_name", JavaType: "String"}, "userName"},
+		{"列名等于前缀时不去除", []string{"f_"}, Field{Name: "f_", JavaType: "String"}, "f"},
+		{"先去前缀再去 is", []string{"f_"}, Field{Name: "f_is_valid", JavaType: "Boolean"}, "valid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NamingConfig{ColumnPrefixes: tt.prefixes}
+			if got := n.PropertyName(tt.field); got != tt.want {
+				t.Errorf("PropertyName(%s) = %s, want %s", tt.field.Name, got, tt.want)
+			}
+		})
+	}
+}
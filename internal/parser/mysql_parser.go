@@ -13,37 +13,61 @@ import (
 type MySQLParser struct{}
 
 func (p *MySQLParser) Parse(sql string) (model.TableInfo, error) {
+	return firstTable(p.ParseAll(sql))
+}
+
+func (p *MySQLParser) ParseAll(sql string) ([]model.TableInfo, error) {
 	stmtNodes, err := parser.New().Parse(sql, mysql.DefaultCharset, "")
 	if err != nil {
-		return model.TableInfo{}, fmt.Errorf("failed to parse MySQL SQL: %w", err)
+		return nil, fmt.Errorf("failed to parse MySQL SQL: %w", err)
 	}
 
 	if len(stmtNodes) == 0 {
-		return model.TableInfo{}, fmt.Errorf("no SQL statement found")
+		return nil, fmt.Errorf("no SQL statement found")
 	}
 
-	var createTableStmt *ast.CreateTableStmt
+	var tables []model.TableInfo
 	for _, stmtNode := range stmtNodes {
 		if stmt, ok := stmtNode.(*ast.CreateTableStmt); ok {
-			createTableStmt = stmt
-			break
+			tables = append(tables, mysqlTable(stmt))
 		}
 	}
 
-	if createTableStmt == nil {
-		return model.TableInfo{}, fmt.Errorf("no CREATE TABLE statement found")
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no CREATE TABLE statement found")
 	}
+	return tables, nil
+}
 
+func mysqlTable(createTableStmt *ast.CreateTableStmt) model.TableInfo {
 	tableName := createTableStmt.Table.Name.String()
 	fields := make([]model.Field, 0, len(createTableStmt.Cols))
 
-	// 查找主键列
+	// 查找主键列，包括表级 PRIMARY KEY (a, b) 和列级 PRIMARY KEY
 	primaryKeys := make(map[string]bool)
+	var foreignKeys []model.ForeignKey
 	for _, cons := range createTableStmt.Constraints {
-		if cons.Tp == ast.ConstraintPrimaryKey {
+		switch cons.Tp {
+		case ast.ConstraintPrimaryKey:
 			for _, key := range cons.Keys {
 				primaryKeys[key.Column.Name.L] = true
 			}
+		case ast.ConstraintForeignKey:
+			var columns []string
+			for _, key := range cons.Keys {
+				columns = append(columns, key.Column.Name.String())
+			}
+			foreignKeys = append(foreignKeys, mysqlForeignKey(columns, cons.Refer))
+		}
+	}
+	for _, col := range createTableStmt.Cols {
+		for _, opt := range col.Options {
+			switch opt.Tp {
+			case ast.ColumnOptionPrimaryKey:
+				primaryKeys[col.Name.Name.L] = true
+			case ast.ColumnOptionReference:
+				foreignKeys = append(foreignKeys, mysqlForeignKey([]string{col.Name.Name.String()}, opt.Refer))
+			}
 		}
 	}
 
@@ -51,28 +75,64 @@ func (p *MySQLParser) Parse(sql string) (model.TableInfo, error) {
 		fieldName := col.Name.Name.String()
 		fieldType := col.Tp.InfoSchemaStr()
 		comment := ""
+		notNull, hasDefault, autoIncrement := false, false, false
 
 		for _, opt := range col.Options {
-			if opt.Tp == ast.ColumnOptionComment {
+			switch opt.Tp {
+			case ast.ColumnOptionComment:
 				comment = opt.Expr.GetDatum().GetString()
-				break
+			case ast.ColumnOptionNotNull, ast.ColumnOptionPrimaryKey:
+				notNull = true
+			case ast.ColumnOptionDefaultValue:
+				hasDefault = true
+			case ast.ColumnOptionAutoIncrement:
+				hasDefault, autoIncrement = true, true
 			}
 		}
 
 		isId := primaryKeys[strings.ToLower(fieldName)]
-		// 兼容旧的逻辑，如果有名为id的列也视为主键
-		if !isId && strings.ToLower(fieldName) == "id" {
+		// 兼容旧的逻辑，没有声明主键时名为id的列视为主键
+		if len(primaryKeys) == 0 && strings.ToLower(fieldName) == "id" {
 			isId = true
 		}
 
-		fields = append(fields, model.Field{
-			Name:     fieldName,
-			Type:     fieldType,
-			JavaType: DefaultTypeMapper.Map(fieldName, "mysql"),
-			Comment:  comment,
-			IsId:     isId,
-		})
+		field := model.Field{
+			Name:          fieldName,
+			Type:          fieldType,
+			JavaType:      DefaultTypeMapper.Map(fieldType, "mysql"),
+			Comment:       comment,
+			IsId:          isId,
+			NotNull:       notNull || primaryKeys[strings.ToLower(fieldName)],
+			HasDefault:    hasDefault,
+			AutoIncrement: autoIncrement,
+		}
+		if col.Tp.Tp == mysql.TypeEnum {
+			field.EnumValues = col.Tp.Elems
+		}
+		if col.Tp.Flen > 0 {
+			setFieldSize(&field, col.Tp.Flen, col.Tp.Decimal)
+		}
+		fields = append(fields, field)
+	}
+
+	var comment string
+	for _, opt := range createTableStmt.Options {
+		if opt.Tp == ast.TableOptionComment {
+			comment = opt.StrValue
+		}
 	}
 
-	return model.TableInfo{TableName: tableName, Fields: fields}, nil
+	return model.TableInfo{TableName: tableName, DbType: "mysql", Comment: comment, Fields: fields, ForeignKeys: foreignKeys}
+}
+
+func mysqlForeignKey(columns []string, refer *ast.ReferenceDef) model.ForeignKey {
+	fk := model.ForeignKey{Columns: columns}
+	if refer == nil {
+		return fk
+	}
+	fk.RefTable = refer.Table.Name.String()
+	for _, col := range refer.IndexColNames {
+		fk.RefColumns = append(fk.RefColumns, col.Column.Name.String())
+	}
+	return fk
 }
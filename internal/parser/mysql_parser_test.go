@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"mybatis-plus-generator/internal/model"
+)
+
+func parseMySQL(t *testing.T, sql string) model.TableInfo {
+	t.Helper()
+	table, err := (&MySQLParser{}).Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return table
+}
+
+func fieldByName(t *testing.T, table model.TableInfo, name string) model.Field {
+	t.Helper()
+	for _, f := range table.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	t.Fatalf("表 %s 中没有列 %s", table.TableName, name)
+	return model.Field{}
+}
+
+func TestMySQLJavaTypes(t *testing.T) {
+	// Java 类型由列类型推导，而不是列名
+	table := parseMySQL(t, `CREATE TABLE t (
+		id bigint PRIMARY KEY,
+		is_deleted tinyint(1),
+		level tinyint,
+		amount decimal(10,2),
+		name varchar(64) COMMENT '名称',
+		created_at datetime)`)
+	tests := map[string]string{
+		"id":         "Long",
+		"is_deleted": "Boolean",
+		"level":      "Integer",
+		"amount":     "BigDecimal",
+		"name":       "String",
+		"created_at": "LocalDateTime",
+	}
+	for column, want := range tests {
+		if got := fieldByName(t, table, column).JavaType; got != want {
+			t.Errorf("%s JavaType = %s, want %s", column, got, want)
+		}
+	}
+	if c := fieldByName(t, table, "name").Comment; c != "名称" {
+		t.Errorf("name Comment = %q", c)
+	}
+}
+
+func idColumns(table model.TableInfo) []string {
+	var columns []string
+	for _, f := range table.Fields {
+		if f.IsId {
+			columns = append(columns, f.Name)
+		}
+	}
+	return columns
+}
+
+func TestMySQLPrimaryKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{"列级主键", "CREATE TABLE t (uid bigint PRIMARY KEY, id bigint)", []string{"uid"}},
+		{"表级主键", "CREATE TABLE t (id bigint, code varchar(8), PRIMARY KEY (code))", []string{"code"}},
+		{"联合主键", "CREATE TABLE t (order_id bigint NOT NULL, line_no int NOT NULL, sku char(8), PRIMARY KEY (order_id, line_no))", []string{"order_id", "line_no"}},
+		{"没有主键时 id 列视为主键", "CREATE TABLE t (ID bigint, msg text)", []string{"ID"}},
+		{"没有主键也没有 id 列", "CREATE TABLE t (uid bigint, msg text)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idColumns(parseMySQL(t, tt.sql)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("主键列 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	table := parseMySQL(t, "CREATE TABLE t (order_id bigint, line_no int, PRIMARY KEY (order_id, line_no))")
+	for _, f := range table.Fields {
+		if !f.NotNull {
+			t.Errorf("主键列 %s 应为 NotNull", f.Name)
+		}
+	}
+}
+
+func TestMySQLParseAll(t *testing.T) {
+	tables, err := (&MySQLParser{}).ParseAll(`
+CREATE TABLE t_order (id bigint NOT NULL AUTO_INCREMENT, PRIMARY KEY (id)) COMMENT='订单';
+INSERT INTO t_order (id) VALUES (1);
+CREATE TABLE t_item (id bigint PRIMARY KEY);`)
+	if err != nil {
+		t.Fatalf("ParseAll: %v", err)
+	}
+	var names []string
+	for _, table := range tables {
+		names = append(names, table.TableName)
+	}
+	if want := []string{"t_order", "t_item"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("tables = %v, want %v", names, want)
+	}
+	if tables[0].Comment != "订单" || tables[0].DbType != "mysql" {
+		t.Errorf("t_order Comment/DbType = %q/%q", tables[0].Comment, tables[0].DbType)
+	}
+
+	if table := parseMySQL(t, "CREATE TABLE a (id int); CREATE TABLE b (id int);"); table.TableName != "a" {
+		t.Errorf("Parse = %s, want first table a", table.TableName)
+	}
+	if _, err := (&MySQLParser{}).ParseAll("SELECT 1;"); err == nil {
+		t.Error("没有 CREATE TABLE 时应返回错误")
+	}
+}
+
+func TestMySQLForeignKeys(t *testing.T) {
+	table := parseMySQL(t, `CREATE TABLE t_item (
+		order_id bigint NOT NULL,
+		line_no int NOT NULL,
+		user_id bigint REFERENCES t_user (id),
+		PRIMARY KEY (order_id, line_no),
+		CONSTRAINT fk_order FOREIGN KEY (order_id, line_no) REFERENCES t_order_line (order_id, line_no))`)
+	// 表级外键在前，列级 REFERENCES 在后
+	want := []model.ForeignKey{
+		{Columns: []string{"order_id", "line_no"}, RefTable: "t_order_line", RefColumns: []string{"order_id", "line_no"}},
+		{Columns: []string{"user_id"}, RefTable: "t_user", RefColumns: []string{"id"}},
+	}
+	if !reflect.DeepEqual(table.ForeignKeys, want) {
+		t.Errorf("ForeignKeys = %+v, want %+v", table.ForeignKeys, want)
+	}
+}
+
+func TestMySQLColumns(t *testing.T) {
+	table := parseMySQL(t, `CREATE TABLE t (
+		id bigint AUTO_INCREMENT PRIMARY KEY,
+		is_deleted tinyint(1) NOT NULL DEFAULT 0,
+		level tinyint,
+		amount decimal(10,2),
+		rate decimal(5),
+		name varchar(64),
+		code char(8),
+		status enum('NEW','PAID'),
+		created_at datetime)`)
+	tests := []struct {
+		column            string
+		javaType          string
+		length, precision int
+		scale             int
+		notNull, dflt     bool
+	}{
+		{"id", "Long", 0, 0, 0, true, true},
+		{"is_deleted", "Boolean", 0, 0, 0, true, true},
+		{"level", "Integer", 0, 0, 0, false, false},
+		{"amount", "BigDecimal", 0, 10, 2, false, false},
+		{"rate", "BigDecimal", 0, 5, 0, false, false},
+		{"name", "String", 64, 0, 0, false, false},
+		{"code", "String", 8, 0, 0, false, false},
+		{"status", "String", 0, 0, 0, false, false},
+		{"created_at", "LocalDateTime", 0, 0, 0, false, false},
+	}
+	for _, tt := range tests {
+		f := fieldByName(t, table, tt.column)
+		if f.JavaType != tt.javaType {
+			t.Errorf("%s JavaType = %s, want %s", tt.column, f.JavaType, tt.javaType)
+		}
+		if f.Length != tt.length || f.Precision != tt.precision || f.Scale != tt.scale {
+			t.Errorf("%s Length/Precision/Scale = %d/%d/%d, want %d/%d/%d", tt.column, f.Length, f.Precision, f.Scale, tt.length, tt.precision, tt.scale)
+		}
+		if f.NotNull != tt.notNull || f.HasDefault != tt.dflt {
+			t.Errorf("%s NotNull/HasDefault = %v/%v, want %v/%v", tt.column, f.NotNull, f.HasDefault, tt.notNull, tt.dflt)
+		}
+	}
+	if got := fieldByName(t, table, "status").EnumValues; !reflect.DeepEqual(got, []string{"NEW", "PAID"}) {
+		t.Errorf("status EnumValues = %v", got)
+	}
+	if !fieldByName(t, table, "id").AutoIncrement || fieldByName(t, table, "is_deleted").AutoIncrement {
+		t.Error("只有 AUTO_INCREMENT 列应标记为自增")
+	}
+}
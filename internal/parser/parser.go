@@ -8,7 +8,10 @@ import (
 
 // Parser 是一个可以解析SQL DDL的接口
 type Parser interface {
+	// Parse 返回第一个 CREATE TABLE 语句对应的表
 	Parse(sql string) (model.TableInfo, error)
+	// ParseAll 按出现顺序返回所有 CREATE TABLE 语句对应的表
+	ParseAll(sql string) ([]model.TableInfo, error)
 }
 
 func NewParser(dbType string) (Parser, error) {
@@ -21,3 +24,25 @@ func NewParser(dbType string) (Parser, error) {
 		return nil, fmt.Errorf("unsupported database type: %s", dbType)
 	}
 }
+
+// setFieldSize 根据 SQL 基础类型记录字符长度或数值精度
+func setFieldSize(field *model.Field, size, scale int) {
+	baseType := strings.ToUpper(strings.TrimSpace(strings.Split(field.Type, "(")[0]))
+	switch baseType {
+	case "CHAR", "CHARACTER", "BPCHAR", "VARCHAR", "CHARACTER VARYING", "BINARY", "VARBINARY":
+		field.Length = size
+	case "DECIMAL", "NUMERIC":
+		field.Precision = size
+		if scale > 0 {
+			field.Scale = scale
+		}
+	}
+}
+
+// firstTable 取 ParseAll 结果中的第一张表
+func firstTable(tables []model.TableInfo, err error) (model.TableInfo, error) {
+	if err != nil {
+		return model.TableInfo{}, err
+	}
+	return tables[0], nil
+}
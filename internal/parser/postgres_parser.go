@@ -11,18 +11,26 @@ import (
 type PostgreSQLParser struct{}
 
 func (p *PostgreSQLParser) Parse(sql string) (model.TableInfo, error) {
+	return firstTable(p.ParseAll(sql))
+}
+
+func (p *PostgreSQLParser) ParseAll(sql string) ([]model.TableInfo, error) {
 	result, err := pg_query.Parse(sql)
 	if err != nil {
-		return model.TableInfo{}, fmt.Errorf("failed to parse PostgreSQL SQL: %w", err)
+		return nil, fmt.Errorf("failed to parse PostgreSQL SQL: %w", err)
 	}
 
-	var tableInfo model.TableInfo
+	var tables []model.TableInfo
 	// 按表存储列注释: map[tableName]map[columnName]comment
 	columnComments := make(map[string]map[string]string)
 	// 按表存储表注释: map[tableName]string
 	tableComments := make(map[string]string)
 	// 按表存储主键: map[tableName]map[columnName]bool
 	primaryKeys := make(map[string]map[string]bool)
+	// 按表存储外键: map[tableName][]ForeignKey
+	foreignKeys := make(map[string][]model.ForeignKey)
+	// 按类型名存储 CREATE TYPE ... AS ENUM 的取值
+	enumTypes := make(map[string][]string)
 
 	// --- 第一遍遍历：收集所有注释和主键信息，并按表名归类 ---
 	for _, stmt := range result.GetStmts() {
@@ -47,13 +55,23 @@ func (p *PostgreSQLParser) Parse(sql string) (model.TableInfo, error) {
 					}
 				}
 			case pg_query.ObjectType_OBJECT_TABLE:
-				if rangeVar := commentStmt.GetObject().GetRangeVar(); rangeVar != nil {
-					tableName := rangeVar.GetRelname()
-					tableComments[tableName] = comment
+				// 表名为 schema.table 形式的名称列表，取最后一部分
+				if listNode := commentStmt.GetObject().GetList(); listNode != nil {
+					if names := stringValues(listNode.GetItems()); len(names) > 0 {
+						tableComments[names[len(names)-1]] = comment
+					}
+				} else if rangeVar := commentStmt.GetObject().GetRangeVar(); rangeVar != nil {
+					tableComments[rangeVar.GetRelname()] = comment
 				}
 			}
 		}
 
+		if enumStmt := stmt.GetStmt().GetCreateEnumStmt(); enumStmt != nil {
+			if names := stringValues(enumStmt.GetTypeName()); len(names) > 0 {
+				enumTypes[names[len(names)-1]] = stringValues(enumStmt.GetVals())
+			}
+		}
+
 		// 2. 解析建表语句 (CREATE TABLE) 以收集主键信息
 		if createStmt := stmt.GetStmt().GetCreateStmt(); createStmt != nil {
 			tableName := createStmt.GetRelation().GetRelname()
@@ -69,10 +87,26 @@ func (p *PostgreSQLParser) Parse(sql string) (model.TableInfo, error) {
 				}
 			}
 			for _, elt := range createStmt.GetTableElts() {
+				// 表级约束 PRIMARY KEY (a, b) 也出现在 TableElts 中
+				if c := elt.GetConstraint(); c != nil {
+					switch c.GetContype() {
+					case pg_query.ConstrType_CONSTR_PRIMARY:
+						for _, key := range c.GetKeys() {
+							primaryKeys[tableName][key.GetString_().GetSval()] = true
+						}
+					case pg_query.ConstrType_CONSTR_FOREIGN:
+						foreignKeys[tableName] = append(foreignKeys[tableName], postgresForeignKey(stringValues(c.GetFkAttrs()), c))
+					}
+				}
 				if c := elt.GetColumnDef(); c != nil {
 					for _, constraint := range c.GetConstraints() {
-						if cons := constraint.GetConstraint(); cons != nil && cons.GetContype() == pg_query.ConstrType_CONSTR_PRIMARY {
+						cons := constraint.GetConstraint()
+						switch {
+						case cons == nil:
+						case cons.GetContype() == pg_query.ConstrType_CONSTR_PRIMARY:
 							primaryKeys[tableName][c.GetColname()] = true
+						case cons.GetContype() == pg_query.ConstrType_CONSTR_FOREIGN:
+							foreignKeys[tableName] = append(foreignKeys[tableName], postgresForeignKey([]string{c.GetColname()}, cons))
 						}
 					}
 				}
@@ -84,7 +118,7 @@ func (p *PostgreSQLParser) Parse(sql string) (model.TableInfo, error) {
 	for _, stmt := range result.GetStmts() {
 		if createStmt := stmt.GetStmt().GetCreateStmt(); createStmt != nil {
 			tableName := createStmt.GetRelation().GetRelname()
-			tableInfo.TableName = tableName
+			tableInfo := model.TableInfo{TableName: tableName, DbType: "postgresql", Comment: tableComments[tableName], ForeignKeys: foreignKeys[tableName]}
 
 			for _, elt := range createStmt.GetTableElts() {
 				if colDef := elt.GetColumnDef(); colDef != nil {
@@ -92,10 +126,25 @@ func (p *PostgreSQLParser) Parse(sql string) (model.TableInfo, error) {
 					typeName := formatPostgresTypeName(colDef.GetTypeName())
 
 					isId := primaryKeys[tableName][colName]
-					if !isId && strings.ToLower(colName) == "id" {
+					// 没有声明主键时，名为 id 的列视为主键
+					if len(primaryKeys[tableName]) == 0 && strings.ToLower(colName) == "id" {
 						isId = true
 					}
 
+					notNull := colDef.GetIsNotNull() || primaryKeys[tableName][colName]
+					autoIncrement := postgresSerialTypes[strings.ToLower(typeName)]
+					hasDefault := autoIncrement
+					for _, constraint := range colDef.GetConstraints() {
+						switch constraint.GetConstraint().GetContype() {
+						case pg_query.ConstrType_CONSTR_NOTNULL:
+							notNull = true
+						case pg_query.ConstrType_CONSTR_DEFAULT, pg_query.ConstrType_CONSTR_GENERATED:
+							hasDefault = true
+						case pg_query.ConstrType_CONSTR_IDENTITY:
+							hasDefault, autoIncrement = true, true
+						}
+					}
+
 					var comment string
 					if tableColumnComments, ok := columnComments[tableName]; ok {
 						comment = tableColumnComments[colName]
@@ -105,22 +154,57 @@ func (p *PostgreSQLParser) Parse(sql string) (model.TableInfo, error) {
 						Name: colName,
 						Type: typeName,
 						// **【优化】** 使用新的TypeMapper进行类型转换
-						JavaType: DefaultTypeMapper.Map(typeName, "postgresql"),
-						Comment:  comment,
-						IsId:     isId,
+						JavaType:      DefaultTypeMapper.Map(typeName, "postgresql"),
+						Comment:       comment,
+						IsId:          isId,
+						NotNull:       notNull,
+						HasDefault:    hasDefault,
+						AutoIncrement: autoIncrement,
+						EnumValues:    enumTypes[typeName],
+					}
+					if typmods := postgresTypmods(colDef.GetTypeName()); len(typmods) > 0 {
+						scale := 0
+						if len(typmods) > 1 {
+							scale = typmods[1]
+						}
+						setFieldSize(&field, typmods[0], scale)
 					}
 					tableInfo.Fields = append(tableInfo.Fields, field)
 				}
 			}
-			break
+			tables = append(tables, tableInfo)
 		}
 	}
 
-	if tableInfo.TableName == "" {
-		return model.TableInfo{}, fmt.Errorf("no CREATE TABLE statement found in SQL")
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no CREATE TABLE statement found in SQL")
 	}
 
-	return tableInfo, nil
+	return tables, nil
+}
+
+// postgresForeignKey 根据 FOREIGN KEY/REFERENCES 约束构造外键，columns 为本表的外键列
+func postgresForeignKey(columns []string, c *pg_query.Constraint) model.ForeignKey {
+	return model.ForeignKey{
+		Columns:    columns,
+		RefTable:   c.GetPktable().GetRelname(),
+		RefColumns: stringValues(c.GetPkAttrs()),
+	}
+}
+
+// stringValues 取出 String 节点列表中的值
+func stringValues(nodes []*pg_query.Node) []string {
+	var values []string
+	for _, node := range nodes {
+		values = append(values, node.GetString_().GetSval())
+	}
+	return values
+}
+
+// postgresSerialTypes 是自带序列默认值的类型
+var postgresSerialTypes = map[string]bool{
+	"serial": true, "serial2": true, "serial4": true, "serial8": true,
+	"smallserial": true, "bigserial": true,
 }
 
 func formatPostgresTypeName(typeName *pg_query.TypeName) string {
@@ -134,3 +218,14 @@ func formatPostgresTypeName(typeName *pg_query.TypeName) string {
 	}
 	return "" // Or some other default
 }
+
+// postgresTypmods 返回类型修饰符，如 varchar(64) -> [64]，numeric(10,2) -> [10 2]
+func postgresTypmods(typeName *pg_query.TypeName) []int {
+	var mods []int
+	for _, node := range typeName.GetTypmods() {
+		if aConst := node.GetAConst(); aConst != nil && aConst.GetIval() != nil {
+			mods = append(mods, int(aConst.GetIval().GetIval()))
+		}
+	}
+	return mods
+}
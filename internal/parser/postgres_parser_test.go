@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"mybatis-plus-generator/internal/model"
+)
+
+func parsePostgres(t *testing.T, sql string) model.TableInfo {
+	t.Helper()
+	table, err := (&PostgreSQLParser{}).Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return table
+}
+
+func TestPostgresPrimaryKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{"列级主键", "CREATE TABLE t (uid bigint PRIMARY KEY, id bigint)", []string{"uid"}},
+		{"联合主键", "CREATE TABLE t (order_id bigint, line_no int, sku text, PRIMARY KEY (order_id, line_no))", []string{"order_id", "line_no"}},
+		{"具名联合主键", "CREATE TABLE t (a int, b int, CONSTRAINT pk_t PRIMARY KEY (b, a))", []string{"a", "b"}},
+		{"没有主键时 id 列视为主键", "CREATE TABLE t (id bigint, msg text)", []string{"id"}},
+		{"没有主键也没有 id 列", "CREATE TABLE t (uid bigint, msg text)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idColumns(parsePostgres(t, tt.sql)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("主键列 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	table := parsePostgres(t, "CREATE TABLE t (order_id bigint, line_no int, PRIMARY KEY (order_id, line_no))")
+	for _, f := range table.Fields {
+		if !f.NotNull {
+			t.Errorf("主键列 %s 应为 NotNull", f.Name)
+		}
+	}
+}
+
+func TestPostgresParseAll(t *testing.T) {
+	tables, err := (&PostgreSQLParser{}).ParseAll(`
+CREATE TABLE orders (id bigserial PRIMARY KEY, name varchar(64));
+CREATE TABLE public.order_items (id bigint PRIMARY KEY);
+COMMENT ON TABLE orders IS '订单';
+COMMENT ON TABLE public.order_items IS '订单明细';
+COMMENT ON COLUMN orders.name IS '名称';`)
+	if err != nil {
+		t.Fatalf("ParseAll: %v", err)
+	}
+	if len(tables) != 2 || tables[0].TableName != "orders" || tables[1].TableName != "order_items" {
+		t.Fatalf("tables = %+v", tables)
+	}
+	if tables[0].DbType != "postgresql" {
+		t.Errorf("DbType = %q", tables[0].DbType)
+	}
+	if tables[0].Comment != "订单" || tables[1].Comment != "订单明细" {
+		t.Errorf("table comments = %q/%q", tables[0].Comment, tables[1].Comment)
+	}
+	if c := fieldByName(t, tables[0], "name").Comment; c != "名称" {
+		t.Errorf("name Comment = %q", c)
+	}
+	if _, err := (&PostgreSQLParser{}).ParseAll("SELECT 1;"); err == nil {
+		t.Error("没有 CREATE TABLE 时应返回错误")
+	}
+}
+
+func TestPostgresForeignKeys(t *testing.T) {
+	table := parsePostgres(t, `CREATE TABLE order_items (
+		order_id bigint,
+		line_no int,
+		user_id bigint REFERENCES users (id),
+		PRIMARY KEY (order_id, line_no),
+		FOREIGN KEY (order_id, line_no) REFERENCES order_lines (order_id, line_no))`)
+	// 外键按声明顺序返回
+	want := []model.ForeignKey{
+		{Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		{Columns: []string{"order_id", "line_no"}, RefTable: "order_lines", RefColumns: []string{"order_id", "line_no"}},
+	}
+	if !reflect.DeepEqual(table.ForeignKeys, want) {
+		t.Errorf("ForeignKeys = %+v, want %+v", table.ForeignKeys, want)
+	}
+}
+
+func TestPostgresColumns(t *testing.T) {
+	table := parsePostgres(t, `CREATE TABLE t (
+		id bigserial PRIMARY KEY,
+		is_deleted boolean NOT NULL DEFAULT false,
+		amount numeric(10,2),
+		rate numeric(5),
+		name varchar(64),
+		code char(8),
+		seq int NOT NULL GENERATED ALWAYS AS IDENTITY,
+		created_at timestamp)`)
+	tests := []struct {
+		column            string
+		javaType          string
+		length, precision int
+		scale             int
+		notNull, dflt     bool
+	}{
+		{"id", "Long", 0, 0, 0, true, true},
+		{"is_deleted", "Boolean", 0, 0, 0, true, true},
+		{"amount", "BigDecimal", 0, 10, 2, false, false},
+		{"rate", "BigDecimal", 0, 5, 0, false, false},
+		{"name", "String", 64, 0, 0, false, false},
+		{"code", "String", 8, 0, 0, false, false},
+		{"seq", "Integer", 0, 0, 0, true, true},
+		{"created_at", "LocalDateTime", 0, 0, 0, false, false},
+	}
+	for _, tt := range tests {
+		f := fieldByName(t, table, tt.column)
+		if f.JavaType != tt.javaType {
+			t.Errorf("%s JavaType = %s, want %s", tt.column, f.JavaType, tt.javaType)
+		}
+		if f.Length != tt.length || f.Precision != tt.precision || f.Scale != tt.scale {
+			t.Errorf("%s Length/Precision/Scale = %d/%d/%d, want %d/%d/%d", tt.column, f.Length, f.Precision, f.Scale, tt.length, tt.precision, tt.scale)
+		}
+		if f.NotNull != tt.notNull || f.HasDefault != tt.dflt {
+			t.Errorf("%s NotNull/HasDefault = %v/%v, want %v/%v", tt.column, f.NotNull, f.HasDefault, tt.notNull, tt.dflt)
+		}
+	}
+	for column, want := range map[string]bool{"id": true, "seq": true, "is_deleted": false} {
+		if got := fieldByName(t, table, column).AutoIncrement; got != want {
+			t.Errorf("%s AutoIncrement = %v, want %v", column, got, want)
+		}
+	}
+}
+
+func TestPostgresEnumTypes(t *testing.T) {
+	table := parsePostgres(t, `
+CREATE TYPE order_status AS ENUM ('new', 'paid', 'closed');
+CREATE TABLE orders (id bigint PRIMARY KEY, status order_status NOT NULL, note text)`)
+	status := fieldByName(t, table, "status")
+	if want := []string{"new", "paid", "closed"}; !reflect.DeepEqual(status.EnumValues, want) {
+		t.Errorf("status EnumValues = %v, want %v", status.EnumValues, want)
+	}
+	if status.Type != "order_status" || !status.NotNull {
+		t.Errorf("status Type/NotNull = %s/%v", status.Type, status.NotNull)
+	}
+	if note := fieldByName(t, table, "note"); note.EnumValues != nil {
+		t.Errorf("note EnumValues = %v, want nil", note.EnumValues)
+	}
+}
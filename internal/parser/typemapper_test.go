@@ -0,0 +1,29 @@
+package parser
+
+import "testing"
+
+func TestTypeMapperMap(t *testing.T) {
+	tests := []struct {
+		sqlType, dbType, want string
+	}{
+		{"tinyint(1)", "mysql", "Boolean"},
+		{"TINYINT(1) UNSIGNED", "MySQL", "Boolean"},
+		{"tinyint(4)", "mysql", "Integer"},
+		{"bigint(20) unsigned", "mysql", "Long"},
+		{"decimal(10,2)", "mysql", "BigDecimal"},
+		{"varchar(64)", "mysql", "String"},
+		{"datetime", "mysql", "LocalDateTime"},
+		{"longblob", "mysql", "byte[]"},
+		{"int8", "postgresql", "Long"},
+		{"numeric", "postgresql", "BigDecimal"},
+		{"uuid", "postgresql", "UUID"},
+		{"tinyint(1)", "postgresql", "String"},
+		{"geometry", "mysql", "String"},
+		{"int", "oracle", "String"},
+	}
+	for _, tt := range tests {
+		if got := DefaultTypeMapper.Map(tt.sqlType, tt.dbType); got != tt.want {
+			t.Errorf("Map(%q, %q) = %s, want %s", tt.sqlType, tt.dbType, got, tt.want)
+		}
+	}
+}